@@ -0,0 +1,177 @@
+// Package clttest renders clt tables, progress indicators and other output at a fixed terminal
+// size so that command line tools can test their output deterministically.  Escape sequences
+// are shown as readable markers such as <bold>…</bold> by default, and Golden compares the
+// output with golden files in testdata.
+package clttest
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/BTBurke/clt"
+)
+
+// ColorMode controls how escape sequences appear in rendered output
+type ColorMode int
+
+// Color modes
+const (
+	// Markers replaces escape sequences with readable markers such as <red>…</red>,
+	// <link url>…</link> and <up>
+	Markers ColorMode = iota
+	// Raw leaves escape sequences unchanged
+	Raw
+	// Strip removes all escape sequences
+	Strip
+)
+
+// Terminal describes the terminal that output is rendered for
+type Terminal struct {
+	Width  int
+	Height int
+	Color  ColorMode
+}
+
+// Default is an 80x25 terminal that shows escape sequences as markers
+var Default = Terminal{Width: 80, Height: 25, Color: Markers}
+
+// Table renders t at the size of the terminal.  If the table cannot be laid out, the
+// output is the layout error.
+func (term Terminal) Table(t *clt.Table) string {
+	t.SetSize(term.Width, term.Height)
+	out, err := t.RenderString()
+	if err != nil {
+		return "clttest: " + err.Error() + "\n"
+	}
+	return term.Normalize(out)
+}
+
+// Progress calls run with p writing to a buffer and returns everything p wrote.  run
+// should start p and finish it with Success or Fail.
+func (term Terminal) Progress(p *clt.Progress, run func(p *clt.Progress)) string {
+	return term.Capture(func(w io.Writer) {
+		p.SetWriter(w)
+		run(p)
+	})
+}
+
+// Capture calls fn with a writer and returns everything written to it.  Use it for output
+// that is not a table or progress indicator, such as an InteractiveSession created
+// WithOutput(w).
+func (term Terminal) Capture(fn func(w io.Writer)) string {
+	var out bytes.Buffer
+	fn(&out)
+	return term.Normalize(out.String())
+}
+
+// Normalize converts the escape sequences in s according to the color mode of the terminal
+func (term Terminal) Normalize(s string) string {
+	if term.Color == Raw || !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var out strings.Builder
+	var sgr sgrState
+	for i := 0; i < len(s); {
+		if s[i] != '\x1b' {
+			out.WriteByte(s[i])
+			i++
+			continue
+		}
+		seq := scanEscape(s[i:])
+		i += seq.length
+		if term.Color == Markers {
+			out.WriteString(seq.marker(&sgr))
+		}
+	}
+	return out.String()
+}
+
+// escape is a single escape sequence
+type escape struct {
+	// kind is '[' for CSI, ']' for OSC or the byte following ESC otherwise
+	kind   byte
+	params string
+	final  byte
+	length int
+}
+
+// scanEscape reads the escape sequence at the start of s
+func scanEscape(s string) escape {
+	if len(s) < 2 {
+		return escape{length: len(s)}
+	}
+	e := escape{kind: s[1]}
+	switch e.kind {
+	case '[':
+		// CSI parameters end with a final byte in the range @ to ~
+		i := 2
+		for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+			i++
+		}
+		e.params = s[2:i]
+		if i < len(s) {
+			e.final = s[i]
+			i++
+		}
+		e.length = i
+	case ']':
+		// OSC sequences end with BEL or ESC \
+		i := 2
+		for i < len(s) && s[i] != '\a' && !(s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\') {
+			i++
+		}
+		e.params = s[2:i]
+		switch {
+		case i < len(s) && s[i] == '\a':
+			i++
+		case i < len(s):
+			i += 2
+		}
+		e.length = i
+	default:
+		e.length = 2
+	}
+	return e
+}
+
+// marker returns the readable form of the escape sequence
+func (e escape) marker(sgr *sgrState) string {
+	switch e.kind {
+	case '[':
+		switch {
+		case e.final == 'm':
+			return sgr.apply(e.params)
+		case e.params == "?25" && e.final == 'l':
+			return "<hide-cursor>"
+		case e.params == "?25" && e.final == 'h':
+			return "<show-cursor>"
+		case e.final == 'A':
+			return countMarker("up", e.params)
+		case e.final == 'B':
+			return countMarker("down", e.params)
+		case e.final == 'K' && e.params == "2":
+			return "<clear-line>"
+		case e.final == 'J' && (e.params == "" || e.params == "0"):
+			return "<clear-end>"
+		}
+		return "<csi " + e.params + string(e.final) + ">"
+	case ']':
+		if strings.HasPrefix(e.params, "8;") {
+			parts := strings.SplitN(e.params, ";", 3)
+			if len(parts) < 3 || parts[2] == "" {
+				return "</link>"
+			}
+			return "<link " + parts[2] + ">"
+		}
+		return "<osc " + e.params + ">"
+	}
+	return "<esc " + string(e.kind) + ">"
+}
+
+func countMarker(name string, n string) string {
+	if n == "" || n == "1" {
+		return "<" + name + ">"
+	}
+	return "<" + name + " " + n + ">"
+}
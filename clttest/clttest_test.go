@@ -0,0 +1,66 @@
+package clttest
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/BTBurke/clt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tt := []struct {
+		Name string
+		In   string
+		Out  string
+	}{
+		{Name: "plain", In: "abc", Out: "abc"},
+		{Name: "bold", In: clt.Styled(clt.Bold).ApplyTo("a"), Out: "<bold>a</bold>"},
+		{Name: "composite", In: clt.Styled(clt.Red, clt.Underline).ApplyTo("a"), Out: "<red><underline>a</red></underline>"},
+		{Name: "default color", In: clt.Styled(clt.Default).ApplyTo("a"), Out: "a"},
+		{Name: "change color", In: "\x1b[31ma\x1b[32mb\x1b[0m", Out: "<red>a</red><green>b</green>"},
+		{Name: "background", In: clt.Styled(clt.Background(clt.Blue)).ApplyTo("a"), Out: "<bg-blue>a</bg-blue>"},
+		{Name: "link", In: "\x1b]8;;https://x\x1b\\a\x1b]8;;\x1b\\", Out: "<link https://x>a</link>"},
+		{Name: "cursor", In: "\x1b[?25l\r\x1b[A\x1b[2Kb\x1b[J\x1b[?25h", Out: "<hide-cursor>\r<up><clear-line>b<clear-end><show-cursor>"},
+		{Name: "unknown", In: "\x1b[5Ca\x1b[38m", Out: "<csi 5C>a<sgr 38>"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Out, Default.Normalize(tc.In))
+			assert.Equal(t, tc.In, Terminal{Color: Raw}.Normalize(tc.In))
+		})
+	}
+	assert.Equal(t, "ab", Terminal{Color: Strip}.Normalize("\x1b[1ma\x1b]8;;u\x07b\x1b[22m"))
+}
+
+func TestTable(t *testing.T) {
+	table := clt.NewTable(3)
+	table.Title("Hosts")
+	table.ColumnHeaders("Name", "Region", "Notes")
+	table.AddRow("web-1", "us-east", "serves the public site and the api behind the load balancer")
+	table.AddRow("db-1", "eu-west", "primary")
+	Golden(t, "", Terminal{Width: 60, Height: 25}.Table(table))
+}
+
+func TestProgress(t *testing.T) {
+	p := clt.NewProgressBar("Downloading")
+	out := Default.Progress(p, func(p *clt.Progress) {
+		p.Start()
+		p.Update(0.5)
+		p.Success()
+	})
+	Golden(t, "", out)
+}
+
+func TestCapture(t *testing.T) {
+	out := Default.Capture(func(w io.Writer) {
+		clt.NewInteractiveSession(clt.WithOutput(w)).Warn("disk %d%% full", 90)
+	})
+	assert.Equal(t, fmt.Sprintf("\n<yellow>Warning</yellow>: disk 90%% full\n"), out)
+}
+
+func TestDiffLine(t *testing.T) {
+	assert.Equal(t, 2, diffLine("a\nb", "a\nc"))
+	assert.Equal(t, 2, diffLine("a", "a\nb"))
+}
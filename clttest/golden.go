@@ -0,0 +1,56 @@
+package clttest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("clttest.update", false, "update clttest golden files with the current output")
+
+// UpdateEnv is the environment variable that updates golden files when set to any value, as an
+// alternative to the -clttest.update flag
+const UpdateEnv = "CLT_UPDATE_GOLDEN"
+
+// Golden compares got with the golden file testdata/<name>.golden and fails the test if they
+// differ.  If name is empty, the name of the test is used.  Run the tests with -clttest.update
+// or CLT_UPDATE_GOLDEN=1 to write got as the new golden file.
+func Golden(t testing.TB, name string, got string) {
+	t.Helper()
+	if name == "" {
+		name = strings.Replace(t.Name(), "/", "-", -1)
+	}
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update || os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("clttest: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("clttest: %v", err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("clttest: %v (run with -clttest.update to create it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("clttest: output does not match %s at line %d\n--- want\n%s\n--- got\n%s", path, diffLine(string(want), got), want, got)
+	}
+}
+
+// diffLine returns the number of the first line that differs
+func diffLine(a, b string) int {
+	al, bl := strings.Split(a, "\n"), strings.Split(b, "\n")
+	for i := range al {
+		if i >= len(bl) || al[i] != bl[i] {
+			return i + 1
+		}
+	}
+	return len(al) + 1
+}
@@ -0,0 +1,107 @@
+package clttest
+
+import (
+	"strconv"
+	"strings"
+)
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// sgrState tracks the open text styles so that closing codes can be shown as the matching
+// closing marker
+type sgrState struct {
+	// open is the name of the open style in each category, in the order they were opened
+	open []openStyle
+}
+
+type openStyle struct {
+	category string
+	name     string
+}
+
+// apply returns the markers for the codes in an SGR sequence
+func (s *sgrState) apply(params string) string {
+	if params == "" {
+		params = "0"
+	}
+	var out strings.Builder
+	for _, p := range strings.Split(params, ";") {
+		code, err := strconv.Atoi(p)
+		if err != nil {
+			out.WriteString("<sgr " + p + ">")
+			continue
+		}
+		out.WriteString(s.code(code))
+	}
+	return out.String()
+}
+
+func (s *sgrState) code(code int) string {
+	switch {
+	case code == 0:
+		var out strings.Builder
+		for i := len(s.open) - 1; i >= 0; i-- {
+			out.WriteString("</" + s.open[i].name + ">")
+		}
+		s.open = nil
+		return out.String()
+	case code == 1:
+		return s.start("intensity", "bold")
+	case code == 2:
+		return s.start("intensity", "dim")
+	case code == 3:
+		return s.start("italic", "italic")
+	case code == 4:
+		return s.start("underline", "underline")
+	case code == 5:
+		return s.start("blink", "blink")
+	case code == 7:
+		return s.start("reverse", "reverse")
+	case code == 9:
+		return s.start("strike", "strike")
+	case code == 22:
+		return s.end("intensity")
+	case code == 23:
+		return s.end("italic")
+	case code == 24:
+		return s.end("underline")
+	case code == 25:
+		return s.end("blink")
+	case code == 27:
+		return s.end("reverse")
+	case code == 29:
+		return s.end("strike")
+	case code >= 30 && code <= 37:
+		return s.start("fg", colorNames[code-30])
+	case code == 39:
+		// the default color is no color, so it only ends the current one
+		return s.end("fg")
+	case code >= 40 && code <= 47:
+		return s.start("bg", "bg-"+colorNames[code-40])
+	case code == 49:
+		return s.end("bg")
+	case code >= 90 && code <= 97:
+		return s.start("fg", "bright-"+colorNames[code-90])
+	case code >= 100 && code <= 107:
+		return s.start("bg", "bg-bright-"+colorNames[code-100])
+	}
+	return "<sgr " + strconv.Itoa(code) + ">"
+}
+
+// start opens a style, first closing any other style open in the same category
+func (s *sgrState) start(category, name string) string {
+	out := s.end(category)
+	s.open = append(s.open, openStyle{category: category, name: name})
+	return out + "<" + name + ">"
+}
+
+// end closes the style open in the category, if there is one
+func (s *sgrState) end(category string) string {
+	for i, o := range s.open {
+		if o.category == category {
+			s.open = append(s.open[:i], s.open[i+1:]...)
+			return "</" + o.name + ">"
+		}
+	}
+	return ""
+}
@@ -5,4 +5,7 @@ package clt
 const (
 	showCursor = "\x1b[?25h"
 	hideCursor = "\x1b[?25l"
+	cursorUp   = "\x1b[A"
+	clearLine  = "\x1b[2K"
+	clearToEnd = "\x1b[J"
 )
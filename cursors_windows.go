@@ -5,4 +5,7 @@ package clt
 const (
 	showCursor = ""
 	hideCursor = ""
+	cursorUp   = ""
+	clearLine  = ""
+	clearToEnd = ""
 )
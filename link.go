@@ -0,0 +1,102 @@
+package clt
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// LinkMode controls how hyperlinks are rendered
+type LinkMode int
+
+// Hyperlink rendering modes
+const (
+	// LinkOSC8 renders a clickable link using the OSC 8 escape sequence.  Terminals that do not
+	// support it show only the label.
+	LinkOSC8 LinkMode = iota
+	// LinkText renders the link as "label (url)" for terminals and logs that cannot show
+	// clickable links
+	LinkText
+	// LinkLabel renders only the label and drops the URL
+	LinkLabel
+)
+
+// linkMode is the current mode used to render hyperlinks.  It is accessed atomically so that
+// the mode can be changed while tables are rendered.
+var linkMode = int32(LinkOSC8)
+
+// SetLinkMode sets how hyperlinks created with Link and LinkCell are rendered.  The default is
+// LinkOSC8.  Link uses the mode when it is called, while cells created with LinkCell use the
+// mode when the table is rendered.
+func SetLinkMode(mode LinkMode) {
+	atomic.StoreInt32(&linkMode, int32(mode))
+}
+
+func currentLinkMode() LinkMode {
+	return LinkMode(atomic.LoadInt32(&linkMode))
+}
+
+// Link returns label as a hyperlink to url using the current LinkMode.  The result can be
+// styled with ApplyTo and used in table cells like any other string.  Only the visible text
+// counts toward the width of the cell, and when the label wraps each line remains part of
+// the link.
+func Link(label, url string) string {
+	switch {
+	case url == "":
+		return label
+	case label == "":
+		label = url
+	}
+	switch currentLinkMode() {
+	case LinkText:
+		if label == url {
+			return label
+		}
+		return label + " (" + url + ")"
+	case LinkLabel:
+		return label
+	}
+	return osc8(label, url)
+}
+
+func osc8(label, url string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + label + "\x1b]8;;\x1b\\"
+}
+
+// hyperlink draws a link that wraps on its visible text
+type hyperlink struct {
+	label string
+	url   string
+}
+
+// LinkCell returns a cell that contains label as a hyperlink to url.  Use it with AddStyledRow.
+// The label wraps to the column width with each wrapped line remaining part of the link.
+func LinkCell(label, url string, sty *Style) Cell {
+	return rendererCell(hyperlink{label: label, url: url}, sty)
+}
+
+func (l hyperlink) naturalWidth() int {
+	return displayWidth(l.text())
+}
+
+func (l hyperlink) lines(w int) []string {
+	lines := wrap(l.text(), w)
+	if currentLinkMode() == LinkOSC8 && l.url != "" {
+		for i, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				lines[i] = osc8(line, l.url)
+			}
+		}
+	}
+	return lines
+}
+
+// text returns the visible text of the link
+func (l hyperlink) text() string {
+	if currentLinkMode() == LinkOSC8 {
+		if l.label == "" {
+			return l.url
+		}
+		return l.label
+	}
+	return Link(l.label, l.url)
+}
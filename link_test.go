@@ -0,0 +1,86 @@
+package clt
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink(t *testing.T) {
+	defer SetLinkMode(LinkOSC8)
+
+	l := Link("ticket", "https://example.com/1")
+	assert.Equal(t, "\x1b]8;;https://example.com/1\x1b\\ticket\x1b]8;;\x1b\\", l)
+	assert.Equal(t, 6, displayWidth(l))
+	assert.Equal(t, 6, displayWidth(Styled(Blue).ApplyTo(l)))
+	assert.Equal(t, "ticket", Link("ticket", ""))
+
+	SetLinkMode(LinkText)
+	assert.Equal(t, "ticket (https://example.com/1)", Link("ticket", "https://example.com/1"))
+	assert.Equal(t, "https://example.com/1", Link("", "https://example.com/1"))
+
+	SetLinkMode(LinkLabel)
+	assert.Equal(t, "ticket", Link("ticket", "https://example.com/1"))
+}
+
+func TestLinkCell(t *testing.T) {
+	defer SetLinkMode(LinkOSC8)
+
+	c := LinkCell("dashboard link", "https://example.com/d", nil)
+	assert.Equal(t, 14, c.width)
+	assert.Equal(t, []string{osc8("dashboard", "https://example.com/d"), osc8("link", "https://example.com/d")}, c.renderer.lines(10))
+
+	table := NewTable(1)
+	table.AddStyledRow(c)
+	v := table.view()
+	v.computeColWidths()
+	assert.Equal(t, 14, v.columns[0].computedWidth)
+
+	SetLinkMode(LinkText)
+	v = table.view()
+	v.computeColWidths()
+	assert.Equal(t, 38, v.columns[0].computedWidth)
+
+	c = LinkCell("d", "https://x", nil)
+	assert.Equal(t, 13, c.width)
+	assert.Equal(t, []string{"d (https://x)"}, c.renderer.lines(20))
+}
+
+func TestLinkInRow(t *testing.T) {
+	url := "https://example.com/1"
+	table := NewTable(2)
+	table.AddRow("a", Link("ticket", url))
+	lines := strings.Split(strings.TrimSuffix(table.AsString(), "\n"), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[2], osc8("ticket", url))
+	assert.Equal(t, 11, displayWidth(lines[2]))
+
+	table = NewTable(2, MaxWidth(12))
+	table.AddRow("a", Link("open ticket", url))
+	out := table.AsString()
+	assert.Contains(t, out, "\x1b]8;;"+url+"\x1b\\open\x1b]8;;\x1b\\")
+	assert.Contains(t, out, "\x1b]8;;"+url+"\x1b\\ticket\x1b]8;;\x1b\\")
+}
+
+func TestSetLinkModeConcurrent(t *testing.T) {
+	defer SetLinkMode(LinkOSC8)
+	table := NewTable(1)
+	table.AddStyledRow(LinkCell("ticket", "https://example.com/1", nil))
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			SetLinkMode(LinkMode(i % 3))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			table.AsString()
+		}
+	}()
+	wg.Wait()
+}
@@ -53,6 +53,20 @@ type Progress struct {
 	output      io.Writer
 	wg          sync.WaitGroup
 	mu          sync.Mutex
+
+	// state drawn by the ProgressGroup this progress belongs to
+	group  *ProgressGroup
+	pct    float64
+	done   bool
+	result int
+	added  time.Time
+
+	// timing used for decorations
+	stats progressStats
+
+	// line mode output for logs and other outputs that are not terminals
+	lineMode lineMode
+	lines    lineState
 }
 
 // NewProgressSpinner returns a new spinner with prompt <message>
@@ -110,6 +124,11 @@ func NewLoadingMessage(message string, spinner Spinner, delay time.Duration) *Pr
 	}
 }
 
+// SetWriter sets the output writer if not writing to Stdout.  Call it before Start.
+func (p *Progress) SetWriter(w io.Writer) {
+	p.output = w
+}
+
 // Start launches a Goroutine to render the progress bar or spinner
 // and returns control to the caller for further processing.  Spinner
 // will update automatically every 250ms until Success() or Fail() is
@@ -117,7 +136,23 @@ func NewLoadingMessage(message string, spinner Spinner, delay time.Duration) *Pr
 // must always finally call either Success() or Fail() to terminate
 // the go routine.
 func (p *Progress) Start() {
+	p.stats.begin()
+	if p.group != nil {
+		return
+	}
 	p.wg.Add(1)
+	if p.useLines(p.output) {
+		switch p.style {
+		case bar:
+			p.cf = make(chan float64, 2)
+			go renderBarLines(p, p.cf)
+			p.cf <- 0.0
+		default:
+			p.c = make(chan int)
+			go renderStatusLines(p, p.c)
+		}
+		return
+	}
 	switch p.style {
 	case spinner:
 		p.c = make(chan int)
@@ -135,6 +170,11 @@ func (p *Progress) Start() {
 // Success should be called on a progress bar or spinner
 // after completion is successful
 func (p *Progress) Success() {
+	p.stats.complete()
+	if p.group != nil {
+		p.finish(success)
+		return
+	}
 	switch p.style {
 	case spinner:
 		p.c <- success
@@ -152,6 +192,10 @@ func (p *Progress) Success() {
 // Fail should be called on a progress bar or spinner
 // if a failure occurs
 func (p *Progress) Fail() {
+	if p.group != nil {
+		p.finish(fail)
+		return
+	}
 	switch p.style {
 	case spinner:
 		p.c <- fail
@@ -172,28 +216,42 @@ func renderSpinner(p *Progress, c chan int) {
 	if p.output == nil {
 		p.output = os.Stdout
 	}
-	promptLen := len(p.Prompt)
-	dotLen := p.DisplayLength - promptLen
-	if dotLen < 3 {
-		dotLen = 3
-	}
 	for i := 0; ; i++ {
 		select {
 		case result := <-c:
-			switch result {
-			case success:
-				fmt.Fprintf(p.output, "%s\r%s%s[%s]\n", showCursor, p.Prompt, strings.Repeat(".", dotLen), Styled(Green).ApplyTo("OK"))
-			case fail:
-				fmt.Fprintf(p.output, "%s\r%s%s[%s]\n", showCursor, p.Prompt, strings.Repeat(".", dotLen), Styled(Red).ApplyTo("FAIL"))
-			}
+			fmt.Fprintf(p.output, "%s\r%s\n", showCursor, spinnerResult(p, result))
 			return
 		default:
-			fmt.Fprintf(p.output, "%s\r%s%s[%s]", hideCursor, p.Prompt, strings.Repeat(".", dotLen), spinLookup(i, p.spinsteps))
+			fmt.Fprintf(p.output, "%s\r%s", hideCursor, spinnerLine(p, i))
 			time.Sleep(time.Duration(250) * time.Millisecond)
 		}
 	}
 }
 
+// spinnerLine formats a running spinner at step i
+func spinnerLine(p *Progress, i int) string {
+	return fmt.Sprintf("%s%s[%s]", p.Prompt, spinnerDots(p), spinLookup(i, p.spinsteps))
+}
+
+// spinnerResult formats a finished spinner
+func spinnerResult(p *Progress, result int) string {
+	switch result {
+	case fail:
+		return fmt.Sprintf("%s%s[%s]", p.Prompt, spinnerDots(p), Styled(Red).ApplyTo("FAIL"))
+	default:
+		return fmt.Sprintf("%s%s[%s]", p.Prompt, spinnerDots(p), Styled(Green).ApplyTo("OK"))
+	}
+}
+
+// spinnerDots fills the space between the prompt and the spinner
+func spinnerDots(p *Progress) string {
+	dotLen := p.DisplayLength - len(p.Prompt)
+	if dotLen < 3 {
+		dotLen = 3
+	}
+	return strings.Repeat(".", dotLen)
+}
+
 func renderLoading(p *Progress, c chan int) {
 	defer p.wg.Done()
 	if p.output == nil {
@@ -218,12 +276,17 @@ func renderLoading(p *Progress, c chan int) {
 			fmt.Fprintf(p.output, "%s\r%s\r\n", hideCursor, strings.Repeat(" ", len(p.spinsteps[0])+len(p.Prompt)+3))
 			return
 		default:
-			fmt.Fprintf(p.output, "%s\r%s  %s", hideCursor, spinLookup(i, p.spinsteps), p.Prompt)
+			fmt.Fprintf(p.output, "%s\r%s", hideCursor, loadingLine(p, i))
 			time.Sleep(time.Duration(250) * time.Millisecond)
 		}
 	}
 }
 
+// loadingLine formats a loading message at step i
+func loadingLine(p *Progress, i int) string {
+	return fmt.Sprintf("%s  %s", spinLookup(i, p.spinsteps), p.Prompt)
+}
+
 func spinLookup(i int, steps []string) string {
 	return steps[i%len(steps)]
 }
@@ -235,30 +298,69 @@ func renderBar(p *Progress, c chan float64) {
 	}
 
 	for result := range c {
-		eqLen := int(result * float64(p.DisplayLength))
-		spLen := p.DisplayLength - eqLen
 		switch {
 		case result == -1.0:
-			fmt.Fprintf(p.output, "%s\r%s: [%s] %s", hideCursor, p.Prompt, strings.Repeat("=", p.DisplayLength), Styled(Green).ApplyTo("100%"))
+			fmt.Fprintf(p.output, "%s\r%s", hideCursor, barResult(p, success))
 			fmt.Fprintf(p.output, "%s\n", showCursor)
 			return
 		case result == -2.0:
-			fmt.Fprintf(p.output, "%s\r%s: [%s] %s", hideCursor, p.Prompt, strings.Repeat("X", p.DisplayLength), Styled(Red).ApplyTo("FAIL"))
+			fmt.Fprintf(p.output, "%s\r%s", hideCursor, barResult(p, fail))
 			fmt.Fprintf(p.output, "%s\n", showCursor)
 			return
 		case result >= 0.0:
-			fmt.Fprintf(p.output, "%s\r%s: [%s%s] %2.0f%%", hideCursor, p.Prompt, strings.Repeat("=", eqLen), strings.Repeat(" ", spLen), 100.0*result)
+			fmt.Fprintf(p.output, "%s\r%s", hideCursor, barLine(p, result))
 		}
 
 	}
 }
 
+// barLine formats a running bar that is pct complete
+func barLine(p *Progress, pct float64) string {
+	if p.stats.isCounting() {
+		return p.Prompt + ":" + p.decorations(false)
+	}
+	eqLen := int(pct * float64(p.DisplayLength))
+	spLen := p.DisplayLength - eqLen
+	return fmt.Sprintf("%s: [%s%s] %2.0f%%", p.Prompt, strings.Repeat("=", eqLen), strings.Repeat(" ", spLen), 100.0*pct) + p.decorations(false)
+}
+
+// barResult formats a finished bar
+func barResult(p *Progress, result int) string {
+	if p.stats.isCounting() {
+		return countResult(p, result)
+	}
+	switch result {
+	case fail:
+		return fmt.Sprintf("%s: [%s] %s", p.Prompt, strings.Repeat("X", p.DisplayLength), Styled(Red).ApplyTo("FAIL")) + p.decorations(true)
+	default:
+		return fmt.Sprintf("%s: [%s] %s", p.Prompt, strings.Repeat("=", p.DisplayLength), Styled(Green).ApplyTo("100%")) + p.decorations(true)
+	}
+}
+
+// countResult formats a finished bar with an unknown total
+func countResult(p *Progress, result int) string {
+	switch result {
+	case fail:
+		return fmt.Sprintf("%s: %s", p.Prompt, Styled(Red).ApplyTo("FAIL")) + p.decorations(true)
+	default:
+		return fmt.Sprintf("%s: %s", p.Prompt, Styled(Green).ApplyTo("OK")) + p.decorations(true)
+	}
+}
+
 // Update the progress bar using a number [0, 1.0] to represent
 // the percentage complete
 func (p *Progress) Update(pct float64) {
 	if pct >= 1.0 {
 		pct = 1.0
 	}
+	p.stats.observe(pct)
+	if p.group != nil {
+		p.mu.Lock()
+		p.pct = pct
+		p.mu.Unlock()
+		p.group.redraw()
+		return
+	}
 	p.cf <- pct
 }
 
@@ -268,12 +370,18 @@ func (p *Progress) Increment() {
 		return
 	}
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
 	p.currentStep += 1
 	pct := float64(p.currentStep) / float64(p.steps)
 	if pct >= 1.0 {
 		pct = 1.0
 	}
+	p.stats.observe(pct)
+	if p.group != nil {
+		p.pct = pct
+		p.mu.Unlock()
+		p.group.redraw()
+		return
+	}
+	defer p.mu.Unlock()
 	p.cf <- pct
 }
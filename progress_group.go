@@ -0,0 +1,170 @@
+package clt
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ProgressGroup draws any number of progress bars, spinners and loading messages together,
+// one per line.  The group owns the output and redraws every line in place, so progress
+// indicators updated from many Goroutines don't overwrite each other.  Progress indicators
+// can be added at any time and stay on screen with their final state when they finish.
+type ProgressGroup struct {
+	out   io.Writer
+	items []*Progress
+	prev  []string
+	frame int
+	stop  chan struct{}
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+
+	// line mode output for logs and other outputs that are not terminals
+	lineMode lineMode
+	lines    bool
+}
+
+// NewProgressGroup returns an empty progress group that writes to Stdout
+func NewProgressGroup() *ProgressGroup {
+	return &ProgressGroup{
+		out:   os.Stdout,
+		lines: linesAuto.useLines(os.Stdout),
+	}
+}
+
+// SetWriter sets the output writer if not writing to Stdout.  Call it before Start.
+func (g *ProgressGroup) SetWriter(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.out = w
+	g.lines = g.lineMode.useLines(w)
+}
+
+// Add adds a progress bar, spinner or loading message created with one of the NewProgress
+// functions to the group and returns it.  Don't call Start on a progress indicator in a
+// group.  Use Update, Increment, Success and Fail as usual and the group redraws it.
+func (g *ProgressGroup) Add(p *Progress) *Progress {
+	p.mu.Lock()
+	p.group = g
+	p.added = time.Now()
+	p.mu.Unlock()
+	p.stats.begin()
+
+	g.mu.Lock()
+	g.items = append(g.items, p)
+	g.mu.Unlock()
+	g.redraw()
+	return p
+}
+
+// Start draws the group and launches a Goroutine that animates spinners and loading messages
+// every 250ms.  You must always finally call Stop to restore the cursor.
+func (g *ProgressGroup) Start() {
+	g.mu.Lock()
+	if !g.lines {
+		io.WriteString(g.out, hideCursor)
+	}
+	g.stop = make(chan struct{})
+	g.mu.Unlock()
+	g.redraw()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		tick := time.NewTicker(time.Duration(250) * time.Millisecond)
+		defer tick.Stop()
+		for {
+			select {
+			case <-g.stop:
+				return
+			case <-tick.C:
+				g.mu.Lock()
+				g.frame++
+				g.mu.Unlock()
+				g.redraw()
+			}
+		}
+	}()
+}
+
+// Stop ends animation, draws the final state of every progress indicator and restores the
+// cursor
+func (g *ProgressGroup) Stop() {
+	if g.stop != nil {
+		close(g.stop)
+		g.wg.Wait()
+		g.stop = nil
+	}
+	g.redraw()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.lines {
+		io.WriteString(g.out, showCursor)
+	}
+}
+
+// redraw draws the current line of every progress indicator in the group
+func (g *ProgressGroup) redraw() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.lines {
+		for _, p := range g.items {
+			io.WriteString(g.out, p.groupLogLines())
+		}
+		return
+	}
+	var lines []string
+	for _, p := range g.items {
+		if line, ok := p.line(g.frame); ok {
+			lines = append(lines, line)
+		}
+	}
+	io.WriteString(g.out, redraw(g.prev, lines))
+	g.prev = lines
+}
+
+// groupLogLines returns the lines a progress indicator in a group prints in line mode
+func (p *Progress) groupLogLines() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.style == loading && !p.done && time.Since(p.added) < p.delay {
+		return ""
+	}
+	return p.logLines(p.pct, p.done, p.result)
+}
+
+// finish records the result of a progress indicator in a group and redraws it
+func (p *Progress) finish(result int) {
+	p.mu.Lock()
+	p.done = true
+	p.result = result
+	p.mu.Unlock()
+	p.group.redraw()
+}
+
+// line returns the current line of a progress indicator in a group at animation step i.  It
+// returns false for a loading message that is finished or still within its delay.
+func (p *Progress) line(i int) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch p.style {
+	case spinner:
+		if p.done {
+			return spinnerResult(p, p.result), true
+		}
+		return spinnerLine(p, i), true
+	case bar:
+		if p.done {
+			return barResult(p, p.result), true
+		}
+		return barLine(p, p.pct), true
+	case loading:
+		if p.done || time.Since(p.added) < p.delay {
+			return "", false
+		}
+		return loadingLine(p, i), true
+	}
+	return "", false
+}
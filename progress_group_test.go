@@ -0,0 +1,62 @@
+package clt
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressGroup(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	g := NewProgressGroup()
+	g.SetWriter(out)
+
+	b1 := g.Add(NewProgressBar("one"))
+	b2 := g.Add(NewIncrementalProgressBar(2, "two"))
+	s := g.Add(NewProgressSpinner("spin"))
+	l := g.Add(NewLoadingMessage("loading", Wheel, time.Hour))
+	b1.Start()
+	b1.Update(0.5)
+	b2.Increment()
+	assert.Equal(t, []string{barLine(b1, 0.5), barLine(b2, 0.5), spinnerLine(s, 0)}, g.prev)
+
+	g.Start()
+
+	b1.Success()
+	b2.Fail()
+	s.Success()
+	l.Success()
+	g.Stop()
+
+	assert.Equal(t, []string{barResult(b1, success), barResult(b2, fail), spinnerResult(s, success)}, g.prev)
+	assert.Contains(t, out.String(), hideCursor)
+	assert.True(t, bytes.HasSuffix(out.Bytes(), []byte(showCursor)))
+}
+
+func TestProgressGroupConcurrent(t *testing.T) {
+	g := NewProgressGroup()
+	g.SetWriter(bytes.NewBuffer(nil))
+	g.Start()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p := g.Add(NewIncrementalProgressBar(5, "worker %d", i))
+			for j := 0; j < 5; j++ {
+				p.Increment()
+			}
+			p.Success()
+		}(i)
+	}
+	wg.Wait()
+	g.Stop()
+	assert.Len(t, g.prev, 10)
+	for _, line := range g.prev {
+		assert.Contains(t, line, fmt.Sprintf("[%s]", "===================="))
+	}
+}
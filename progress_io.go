@@ -0,0 +1,198 @@
+package clt
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// redrawInterval is the shortest time between redraws of a bar driven by a reader or writer, so
+// that transfers made of many small reads and writes don't flood the output
+const redrawInterval = 100 * time.Millisecond
+
+// ProgressReader is an io.Reader that shows a progress bar as data is read.  The bar starts
+// with the first Read and finishes when the underlying reader returns io.EOF or an error.  Like
+// Close, io.EOF fails the bar if fewer bytes than the known size were read.  The bar is redrawn
+// at most every 100ms.
+// Reads after the bar is finished, such as retries after a temporary error, still read from
+// the underlying reader but no longer update the bar.
+type ProgressReader struct {
+	r io.Reader
+	progressIO
+}
+
+// NewProgressReader returns a reader that reads from r and shows a progress bar with the
+// prompt format.  size is the total number of bytes that will be read, which is used for the
+// percentage complete and time remaining.  If size is unknown, pass 0 and the bar shows only
+// the number of bytes read and the transfer rate.
+func NewProgressReader(r io.Reader, size int64, format string, args ...interface{}) *ProgressReader {
+	return &ProgressReader{r: r, progressIO: newProgressIO(size, format, args...)}
+}
+
+// Read reads from the underlying reader and updates the progress bar
+func (pr *ProgressReader) Read(b []byte) (int, error) {
+	pr.start()
+	n, err := pr.r.Read(b)
+	if n > 0 {
+		pr.transfer(n)
+	}
+	switch {
+	case err == io.EOF:
+		pr.finish(pr.incomplete())
+	case err != nil:
+		pr.finish(err)
+	}
+	return n, err
+}
+
+// Close finishes the progress bar and closes the underlying reader if it is an io.Closer.  The
+// bar fails if fewer bytes than the known size were read.
+func (pr *ProgressReader) Close() error {
+	pr.finish(pr.incomplete())
+	if c, ok := pr.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// ProgressWriter is an io.Writer that shows a progress bar as data is written.  The bar starts
+// with the first Write and finishes on Close or when the underlying writer returns an error.  The
+// bar is redrawn at most every 100ms.
+type ProgressWriter struct {
+	w io.Writer
+	progressIO
+}
+
+// NewProgressWriter returns a writer that writes to w and shows a progress bar with the prompt
+// format.  size is the total number of bytes that will be written, or 0 if unknown.  See
+// NewProgressReader.
+func NewProgressWriter(w io.Writer, size int64, format string, args ...interface{}) *ProgressWriter {
+	return &ProgressWriter{w: w, progressIO: newProgressIO(size, format, args...)}
+}
+
+// Write writes to the underlying writer and updates the progress bar
+func (pw *ProgressWriter) Write(b []byte) (int, error) {
+	pw.start()
+	n, err := pw.w.Write(b)
+	if n > 0 {
+		pw.transfer(n)
+	}
+	if err != nil {
+		pw.finish(err)
+	}
+	return n, err
+}
+
+// Close finishes the progress bar and closes the underlying writer if it is an io.Closer.  The
+// bar fails if fewer bytes than the known size were written.
+func (pw *ProgressWriter) Close() error {
+	pw.finish(pw.incomplete())
+	if c, ok := pw.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// progressIO drives a progress bar from the number of bytes transferred
+type progressIO struct {
+	p       *Progress
+	size    int64
+	started sync.Once
+	// done is set when the bar is finished, after which transfers no longer update it
+	done bool
+	// drawn is when the bar was last redrawn
+	drawn time.Time
+	mu    sync.Mutex
+}
+
+func newProgressIO(size int64, format string, args ...interface{}) progressIO {
+	p := NewProgressBar(format, args...)
+	p.stats.bytes = true
+	switch {
+	case size > 0:
+		p.SetTotal(size)
+		p.Decorate(ShowCount, ShowByteRate, ShowETA)
+	default:
+		p.stats.counting = true
+		p.Decorate(ShowCount, ShowByteRate)
+	}
+	return progressIO{p: p, size: size}
+}
+
+// Progress returns the progress bar so that it can be added to a ProgressGroup or decorated
+// before the transfer starts
+func (pio *progressIO) Progress() *Progress {
+	return pio.p
+}
+
+func (pio *progressIO) start() {
+	pio.started.Do(pio.p.Start)
+}
+
+// finish marks the bar successful if err is nil and failed otherwise
+func (pio *progressIO) finish(err error) {
+	pio.start()
+	pio.mu.Lock()
+	defer pio.mu.Unlock()
+	if pio.done {
+		return
+	}
+	pio.done = true
+	if err != nil {
+		pio.p.Fail()
+		return
+	}
+	pio.p.Success()
+}
+
+// transfer updates the bar with n more bytes unless it is already finished, such as when a
+// caller retries after an error.  Every byte is counted, but the bar is only redrawn once per
+// redrawInterval.  The result drawn by finish always shows the final count.
+func (pio *progressIO) transfer(n int) {
+	pio.mu.Lock()
+	defer pio.mu.Unlock()
+	if pio.done {
+		return
+	}
+	t := now()
+	draw := t.Sub(pio.drawn) >= redrawInterval
+	if draw {
+		pio.drawn = t
+	}
+	pio.p.transfer(n, draw)
+}
+
+// incomplete returns an error if the size is known and fewer bytes were transferred
+func (pio *progressIO) incomplete() error {
+	s := &pio.p.stats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pio.size > 0 && s.count < pio.size {
+		return fmt.Errorf("transferred %d of %d bytes", s.count, pio.size)
+	}
+	return nil
+}
+
+// transfer records that n more bytes were transferred and redraws the bar if draw is set
+func (p *Progress) transfer(n int, draw bool) {
+	s := &p.stats
+	s.mu.Lock()
+	s.count += int64(n)
+	count, total, counting := s.count, s.total, s.counting
+	s.mu.Unlock()
+
+	if !draw {
+		return
+	}
+	if !counting {
+		p.Update(float64(count) / float64(total))
+		return
+	}
+	s.observe(float64(count))
+	if p.group != nil {
+		p.group.redraw()
+		return
+	}
+	p.cf <- 0
+}
@@ -0,0 +1,152 @@
+package clt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type errReader struct{}
+
+func (errReader) Read(b []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestProgressReader(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+	data := strings.Repeat("x", 4096)
+
+	pr := NewProgressReader(strings.NewReader(data), int64(len(data)), "download")
+	pr.Progress().SetWriter(out)
+	n, err := io.Copy(ioutil.Discard, pr)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4096), n)
+	assert.Contains(t, out.String(), "download: [====================] "+Styled(Green).ApplyTo("100%")+" 4.0 KiB/4.0 KiB 0 B/s"+showCursor+"\n")
+	assert.NoError(t, pr.Close())
+}
+
+func TestProgressReaderUnknownSize(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+
+	pr := NewProgressReader(strings.NewReader(strings.Repeat("x", 2048)), 0, "download")
+	pr.Progress().SetWriter(out)
+	io.Copy(ioutil.Discard, pr)
+	assert.Contains(t, out.String(), "download: 2.0 KiB 0 B/s")
+	assert.Contains(t, out.String(), "download: "+Styled(Green).ApplyTo("OK")+" 2.0 KiB 0 B/s")
+}
+
+func TestProgressReaderError(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+
+	pr := NewProgressReader(errReader{}, 100, "download")
+	pr.Progress().SetWriter(out)
+	_, err := io.Copy(ioutil.Discard, pr)
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), Styled(Red).ApplyTo("FAIL"))
+	assert.NoError(t, pr.Close())
+}
+
+func TestProgressWriter(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+	var dst bytes.Buffer
+
+	pw := NewProgressWriter(&dst, 10, "upload")
+	pw.Progress().SetWriter(out)
+	pw.Write([]byte("12345"))
+	pw.Close()
+	assert.Contains(t, out.String(), "upload: [==========          ] 50% 5 B/10 B 0 B/s eta -:--")
+	assert.Contains(t, out.String(), Styled(Red).ApplyTo("FAIL"))
+	assert.Equal(t, "12345", dst.String())
+}
+
+func TestProgressWriterGroup(t *testing.T) {
+	fakeClock(t)
+	g := NewProgressGroup()
+	g.SetWriter(bytes.NewBuffer(nil))
+
+	pw := NewProgressWriter(ioutil.Discard, 4, "upload")
+	g.Add(pw.Progress())
+	pw.Write([]byte("1234"))
+	pw.Close()
+	g.Stop()
+	assert.Equal(t, []string{"upload: [====================] " + Styled(Green).ApplyTo("100%") + " 4 B/4 B 0 B/s"}, g.prev)
+}
+
+// flakyReader fails the first read with a temporary error and then returns data
+type flakyReader struct {
+	failed bool
+	r      io.Reader
+}
+
+func (f *flakyReader) Read(b []byte) (int, error) {
+	if !f.failed {
+		f.failed = true
+		return 0, errors.New("timeout")
+	}
+	return f.r.Read(b)
+}
+
+func TestProgressReaderRetry(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+
+	pr := NewProgressReader(&flakyReader{r: strings.NewReader("data")}, 4, "download")
+	pr.Progress().SetWriter(out)
+	b := make([]byte, 4)
+	_, err := pr.Read(b)
+	assert.Error(t, err)
+	n, err := pr.Read(b)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	_, err = pr.Read(b)
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, pr.Close())
+	assert.Equal(t, 1, strings.Count(out.String(), Styled(Red).ApplyTo("FAIL")))
+
+	pw := NewProgressWriter(ioutil.Discard, 0, "upload")
+	pw.Progress().SetWriter(out)
+	pw.Close()
+	n, err = pw.Write([]byte("more"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+}
+
+func TestProgressReaderShortEOF(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+
+	pr := NewProgressReader(strings.NewReader("data"), 10, "download")
+	pr.Progress().SetWriter(out)
+	_, err := io.Copy(ioutil.Discard, pr)
+	assert.NoError(t, err)
+	assert.NoError(t, pr.Close())
+	assert.Equal(t, 1, strings.Count(out.String(), Styled(Red).ApplyTo("FAIL")))
+	assert.NotContains(t, out.String(), Styled(Green).ApplyTo("100%"))
+}
+
+func TestProgressWriterThrottle(t *testing.T) {
+	advance := fakeClock(t)
+	out := bytes.NewBuffer(nil)
+
+	pw := NewProgressWriter(ioutil.Discard, 100, "upload")
+	pw.Progress().SetWriter(out)
+	for i := 0; i < 100; i++ {
+		pw.Write([]byte("x"))
+		advance(10 * time.Millisecond)
+	}
+	pw.Close()
+	// the initial frame, one redraw every 100ms and the result
+	assert.Equal(t, 12, strings.Count(out.String(), "\r"))
+	assert.Contains(t, out.String(), "upload: [==================  ] 91%")
+	assert.Contains(t, out.String(), Styled(Green).ApplyTo("100%")+" 100 B/100 B")
+}
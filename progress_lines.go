@@ -0,0 +1,174 @@
+package clt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// lineMode selects between redrawing progress in place and printing it as lines
+type lineMode int
+
+const (
+	linesAuto lineMode = iota
+	linesOn
+	linesOff
+)
+
+const (
+	// milestoneStep is the fraction of progress between lines printed for a bar in line mode
+	milestoneStep = 0.1
+	// countInterval is the time between lines printed in line mode for a bar with an unknown
+	// total
+	countInterval = 10 * time.Second
+)
+
+// LineMode sets whether progress is printed as a series of lines instead of being redrawn in
+// place.  In line mode, a progress indicator prints its prompt when it starts, bars print a line
+// at every 10% of progress, and the result is printed as OK or FAIL without any escape
+// sequences.  By default, line mode is used when the output is a file that is not a terminal,
+// such as a log file or the output of a CI job.  Call it before Start.
+func (p *Progress) LineMode(on bool) *Progress {
+	p.lineMode = linesOff
+	if on {
+		p.lineMode = linesOn
+	}
+	return p
+}
+
+// LineMode sets whether the group prints progress as a series of lines instead of redrawing it
+// in place.  See Progress.LineMode.  Call it before Start.
+func (g *ProgressGroup) LineMode(on bool) *ProgressGroup {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lineMode = linesOff
+	if on {
+		g.lineMode = linesOn
+	}
+	g.lines = on
+	return g
+}
+
+// useLines reports whether output to w uses line mode.  Only files that are not terminals use
+// line mode automatically, so progress written to other writers is unchanged.
+func (mode lineMode) useLines(w io.Writer) bool {
+	switch mode {
+	case linesOn:
+		return true
+	case linesOff:
+		return false
+	}
+	if w == nil {
+		w = os.Stdout
+	}
+	f, ok := w.(*os.File)
+	return ok && !terminal.IsTerminal(int(f.Fd()))
+}
+
+func (p *Progress) useLines(w io.Writer) bool {
+	return p.lineMode.useLines(w)
+}
+
+// lineState tracks what a progress indicator has printed in line mode
+type lineState struct {
+	started   bool
+	finished  bool
+	milestone int
+	printed   time.Time
+}
+
+// logLines returns the lines that a progress indicator prints in line mode for its current
+// state, if any
+func (p *Progress) logLines(pct float64, done bool, result int) string {
+	s := &p.lines
+	if s.finished {
+		return ""
+	}
+	if done && !s.started && p.style == loading {
+		// a loading message that finished within its delay prints nothing
+		s.finished = true
+		return ""
+	}
+	var out string
+	if !s.started {
+		s.started = true
+		s.printed = now()
+		if p.style == loading {
+			out += p.Prompt + "\n"
+		} else {
+			out += p.Prompt + "...\n"
+		}
+	}
+	switch {
+	case done:
+		s.finished = true
+		if p.style == loading {
+			return out
+		}
+		status := "OK"
+		if result == fail {
+			status = "FAIL"
+		}
+		out += fmt.Sprintf("%s: %s", p.Prompt, status)
+		if p.style == bar {
+			out += p.decorations(true)
+		}
+		return out + "\n"
+	case p.style != bar:
+	case p.stats.isCounting():
+		if now().Sub(s.printed) >= countInterval {
+			s.printed = now()
+			out += p.Prompt + ":" + p.decorations(false) + "\n"
+		}
+	default:
+		if m := int(pct/milestoneStep + 1e-9); m > s.milestone && pct < 1 {
+			s.milestone = m
+			out += fmt.Sprintf("%s: %.0f%%", p.Prompt, float64(m)*milestoneStep*100) + p.decorations(false) + "\n"
+		}
+	}
+	return out
+}
+
+// renderBarLines prints a bar in line mode
+func renderBarLines(p *Progress, c chan float64) {
+	defer p.wg.Done()
+	if p.output == nil {
+		p.output = os.Stdout
+	}
+	for result := range c {
+		switch {
+		case result == -1.0:
+			io.WriteString(p.output, p.logLines(1.0, true, success))
+			return
+		case result == -2.0:
+			io.WriteString(p.output, p.logLines(0, true, fail))
+			return
+		case result >= 0.0:
+			io.WriteString(p.output, p.logLines(result, false, success))
+		}
+	}
+}
+
+// renderStatusLines prints a spinner or loading message in line mode.  A loading message that
+// finishes within its delay prints nothing.
+func renderStatusLines(p *Progress, c chan int) {
+	defer p.wg.Done()
+	if p.output == nil {
+		p.output = os.Stdout
+	}
+	if p.style == loading && p.delay > 0 {
+		t := time.NewTimer(p.delay)
+		select {
+		case <-c:
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+	io.WriteString(p.output, p.logLines(0, false, success))
+	result := <-c
+	io.WriteString(p.output, p.logLines(0, true, result))
+}
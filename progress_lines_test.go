@@ -0,0 +1,94 @@
+package clt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseLines(t *testing.T) {
+	f, err := ioutil.TempFile("", "clt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	assert.True(t, linesAuto.useLines(f))
+	assert.False(t, linesAuto.useLines(bytes.NewBuffer(nil)))
+	assert.True(t, linesOn.useLines(bytes.NewBuffer(nil)))
+	assert.False(t, linesOff.useLines(f))
+}
+
+func TestBarLines(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+	p := NewProgressBar("migrate").LineMode(true)
+	p.SetWriter(out)
+	p.Start()
+	p.Update(0.05)
+	p.Update(0.25)
+	p.Update(0.5)
+	p.Update(0.51)
+	p.Success()
+	assert.Equal(t, "migrate...\nmigrate: 20%\nmigrate: 50%\nmigrate: OK\n", out.String())
+}
+
+func TestCountLines(t *testing.T) {
+	advance := fakeClock(t)
+	p := NewProgressWriter(ioutil.Discard, 0, "upload").Progress()
+	p.stats.begin()
+	assert.Equal(t, "upload...\n", p.logLines(0, false, success))
+
+	p.stats.count = 1024
+	p.stats.observe(1024)
+	assert.Equal(t, "", p.logLines(0, false, success))
+
+	advance(countInterval)
+	p.stats.count = 2048
+	p.stats.observe(2048)
+	assert.Equal(t, "upload: 2.0 KiB 102 B/s\n", p.logLines(0, false, success))
+	assert.Equal(t, "upload: OK 2.0 KiB 205 B/s\n", p.logLines(0, true, success))
+	assert.Equal(t, "", p.logLines(0, true, success))
+}
+
+func TestStatusLines(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	p := NewProgressSpinner("connect").LineMode(true)
+	p.SetWriter(out)
+	p.Start()
+	p.Fail()
+	assert.Equal(t, "connect...\nconnect: FAIL\n", out.String())
+
+	out.Reset()
+	l := NewLoadingMessage("loading", Dots, time.Hour).LineMode(true)
+	l.SetWriter(out)
+	l.Start()
+	l.Success()
+	assert.Equal(t, "", out.String())
+
+	l = NewLoadingMessage("loading", Dots, 0).LineMode(true)
+	l.SetWriter(out)
+	l.Start()
+	l.Success()
+	assert.Equal(t, "loading\n", out.String())
+}
+
+func TestProgressGroupLines(t *testing.T) {
+	fakeClock(t)
+	out := bytes.NewBuffer(nil)
+	g := NewProgressGroup().LineMode(true)
+	g.SetWriter(out)
+	g.Start()
+	b := g.Add(NewProgressBar("one"))
+	s := g.Add(NewProgressSpinner("two"))
+	b.Update(0.3)
+	s.Success()
+	b.Success()
+	g.Stop()
+	assert.Equal(t, "one...\ntwo...\none: 30%\ntwo: OK\none: OK\n", out.String())
+}
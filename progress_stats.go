@@ -0,0 +1,244 @@
+package clt
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Decoration is extra information shown after the percentage of a progress bar
+type Decoration int
+
+// Decorations for progress bars
+const (
+	// ShowElapsed shows the time since the bar started, as in "elapsed 1:05"
+	ShowElapsed Decoration = iota
+	// ShowETA shows the estimated time remaining, as in "eta 2:10".  The estimate uses a moving
+	// average of the recent rate of progress so that it doesn't jump on every update.
+	ShowETA
+	// ShowRate shows the throughput in items per second, as in "12.5/s".  The bar needs a total
+	// from NewIncrementalProgressBar or SetTotal.
+	ShowRate
+	// ShowByteRate shows the throughput in bytes per second, as in "1.2 MiB/s".  The bar needs a
+	// total number of bytes from SetTotal.
+	ShowByteRate
+	// ShowCount shows the number of items done and the total, as in "42/100".  The bar needs a
+	// total from NewIncrementalProgressBar or SetTotal, except for bars that count bytes from a
+	// ProgressReader or ProgressWriter.
+	ShowCount
+)
+
+// rateSmoothing is the weight of the newest rate in the moving average used for the ETA
+const rateSmoothing = 0.3
+
+// now returns the current time and can be replaced in tests
+var now = time.Now
+
+// Decorate adds information such as the elapsed time, time remaining and throughput after the
+// percentage of a progress bar.  Decorations are shown in the order given.  Call it before Start.
+func (p *Progress) Decorate(decorations ...Decoration) *Progress {
+	p.stats.mu.Lock()
+	defer p.stats.mu.Unlock()
+	p.stats.decorations = append(p.stats.decorations, decorations...)
+	return p
+}
+
+// SetTotal sets the number of items or bytes that the progress bar represents, which is used to
+// show counts and throughput.  Bars created with NewIncrementalProgressBar already use the number
+// of steps as their total.
+func (p *Progress) SetTotal(total int64) *Progress {
+	p.stats.mu.Lock()
+	defer p.stats.mu.Unlock()
+	p.stats.total = total
+	return p
+}
+
+// progressStats tracks the progress of a bar over time
+type progressStats struct {
+	decorations []Decoration
+	total       int64
+	start       time.Time
+	last        time.Time
+	done        float64
+	// rate is a moving average of the fraction completed per second
+	rate float64
+	// bytes formats counts and totals as sizes
+	bytes bool
+	// counting is set when the total is unknown, so done and rate are counts rather than
+	// fractions of the total
+	counting bool
+	// count is the number of bytes transferred by a ProgressReader or ProgressWriter
+	count int64
+	mu    sync.Mutex
+}
+
+// begin records the start time if the bar has not already started
+func (s *progressStats) begin() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.start.IsZero() {
+		s.start = now()
+		s.last = s.start
+	}
+}
+
+// observe records that the fraction done has been completed
+func (s *progressStats) observe(done float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := now()
+	if s.start.IsZero() {
+		s.start, s.last = t, t
+	}
+	if dt := t.Sub(s.last).Seconds(); dt > 0 {
+		rate := (done - s.done) / dt
+		switch {
+		case s.rate == 0:
+			s.rate = rate
+		default:
+			s.rate = rateSmoothing*rate + (1-rateSmoothing)*s.rate
+		}
+		s.last = t
+	}
+	s.done = done
+}
+
+// complete records that the bar finished successfully
+func (s *progressStats) complete() {
+	s.mu.Lock()
+	counting := s.counting
+	s.mu.Unlock()
+	if !counting {
+		s.observe(1.0)
+	}
+}
+
+func (s *progressStats) isCounting() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counting
+}
+
+// decorations formats the decorations of the bar.  Finished bars show the overall rate and
+// no estimate.
+func (p *Progress) decorations(finished bool) string {
+	s := &p.stats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.decorations) == 0 {
+		return ""
+	}
+	total := s.total
+	if total <= 0 {
+		total = int64(p.steps)
+	}
+	elapsed := time.Duration(0)
+	if !s.start.IsZero() {
+		elapsed = now().Sub(s.start)
+	}
+	rate := s.rate
+	if finished && elapsed > 0 {
+		rate = s.done / elapsed.Seconds()
+	}
+
+	if s.counting {
+		return s.countDecorations(elapsed, rate)
+	}
+
+	var out []string
+	for _, d := range s.decorations {
+		switch d {
+		case ShowElapsed:
+			out = append(out, "elapsed "+formatDuration(elapsed))
+		case ShowETA:
+			switch {
+			case finished:
+			case rate <= 0:
+				out = append(out, "eta -:--")
+			default:
+				out = append(out, "eta "+formatDuration(time.Duration((1-s.done)/rate*float64(time.Second))))
+			}
+		case ShowRate:
+			if total > 0 {
+				out = append(out, formatRate(rate*float64(total))+"/s")
+			}
+		case ShowByteRate:
+			if total > 0 {
+				out = append(out, formatBytes(rate*float64(total))+"/s")
+			}
+		case ShowCount:
+			if total > 0 {
+				out = append(out, s.formatCount(s.done*float64(total))+"/"+s.formatCount(float64(total)))
+			}
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return " " + strings.Join(out, " ")
+}
+
+// countDecorations formats the decorations of a bar with an unknown total, which has no
+// estimate of the time remaining
+func (s *progressStats) countDecorations(elapsed time.Duration, rate float64) string {
+	var out []string
+	for _, d := range s.decorations {
+		switch d {
+		case ShowElapsed:
+			out = append(out, "elapsed "+formatDuration(elapsed))
+		case ShowRate:
+			out = append(out, formatRate(rate)+"/s")
+		case ShowByteRate:
+			out = append(out, formatBytes(rate)+"/s")
+		case ShowCount:
+			out = append(out, s.formatCount(s.done))
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return " " + strings.Join(out, " ")
+}
+
+func (s *progressStats) formatCount(n float64) string {
+	if s.bytes {
+		return formatBytes(n)
+	}
+	return fmt.Sprintf("%d", int64(n+0.5))
+}
+
+// formatDuration formats d as m:ss, or h:mm:ss for an hour or more
+func formatDuration(d time.Duration) string {
+	secs := int64(d.Round(time.Second) / time.Second)
+	if secs < 0 {
+		secs = 0
+	}
+	h, m, sec := secs/3600, secs/60%60, secs%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
+}
+
+// formatRate formats a number of items with one decimal place when it is small
+func formatRate(n float64) string {
+	if n < 10 {
+		return fmt.Sprintf("%.1f", n)
+	}
+	return fmt.Sprintf("%.0f", n)
+}
+
+// formatBytes formats a number of bytes using binary units, as in 1.2 MiB
+func formatBytes(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := 0
+	for n >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[i])
+	}
+	return fmt.Sprintf("%s %s", formatRate(n), units[i])
+}
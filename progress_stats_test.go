@@ -0,0 +1,75 @@
+package clt
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock replaces now with a clock that only moves when advanced.  It is safe to advance
+// while progress indicators are drawn.
+func fakeClock(t *testing.T) func(d time.Duration) {
+	var mu sync.Mutex
+	current := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	t.Cleanup(func() { now = time.Now })
+	return func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = current.Add(d)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0:00", formatDuration(0))
+	assert.Equal(t, "1:05", formatDuration(65*time.Second))
+	assert.Equal(t, "1:00:01", formatDuration(time.Hour+time.Second))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "20 MiB", formatBytes(20*1024*1024))
+}
+
+func TestDecorations(t *testing.T) {
+	advance := fakeClock(t)
+	p := NewIncrementalProgressBar(100, "migrate").Decorate(ShowCount, ShowElapsed, ShowETA, ShowRate)
+	p.stats.begin()
+	assert.Equal(t, " 0/100 elapsed 0:00 eta -:-- 0.0/s", p.decorations(false))
+
+	advance(10 * time.Second)
+	p.stats.observe(0.1)
+	assert.Equal(t, " 10/100 elapsed 0:10 eta 1:30 1.0/s", p.decorations(false))
+
+	// the estimate moves toward a faster rate without jumping to it
+	advance(10 * time.Second)
+	p.stats.observe(0.3)
+	assert.Equal(t, " 30/100 elapsed 0:20 eta 0:54 1.3/s", p.decorations(false))
+
+	advance(10 * time.Second)
+	p.stats.observe(1.0)
+	assert.Equal(t, " 100/100 elapsed 0:30 3.3/s", p.decorations(true))
+}
+
+func TestDecorationsNeedTotal(t *testing.T) {
+	fakeClock(t)
+	p := NewProgressBar("copy").Decorate(ShowCount, ShowByteRate)
+	assert.Equal(t, "", p.decorations(false))
+	p.SetTotal(1024)
+	assert.Equal(t, " 0/1024 0 B/s", p.decorations(false))
+}
+
+func TestBarLineDecorated(t *testing.T) {
+	fakeClock(t)
+	p := NewIncrementalProgressBar(4, "steps").Decorate(ShowCount)
+	p.DisplayLength = 4
+	p.stats.observe(0.5)
+	assert.Equal(t, "steps: [==  ] 50% 2/4", barLine(p, 0.5))
+}
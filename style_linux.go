@@ -75,6 +75,7 @@ var (
 	Bold      = Textstyle{1, 22}
 	Italic    = Textstyle{3, 23}
 	Underline = Textstyle{4, 24}
+	Dim       = Textstyle{2, 22}
 )
 
 // Background returns a style that sets the background to the appropriate color
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"log"
 	"os"
+	"regexp"
 	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
 // Justification sets the default placement of text inside each cell of a column
@@ -25,6 +27,27 @@ type Cell struct {
 	value string
 	width int
 	style *Style
+
+	// prefix is rendered unstyled before the first line of the cell and indent before
+	// every following line.  Both are set at render time for tree guides.
+	prefix string
+	indent string
+
+	// renderer draws cells whose contents depend on the computed column width
+	renderer cellRenderer
+
+	// highlights are the ranges of visible runes in the value drawn with highlightStyle
+	highlights     [][]int
+	highlightStyle *Style
+}
+
+// cellRenderer draws cell contents that depend on the computed width of the column instead
+// of wrapping a fixed value
+type cellRenderer interface {
+	// naturalWidth is the width the cell would use if there is enough space
+	naturalWidth() int
+	// lines renders the cell contents at width w
+	lines(w int) []string
 }
 
 // Title is a special cell that is rendered at the center top of the table that can contain
@@ -38,6 +61,17 @@ type Title struct {
 // Row is a row of cells in a table.  You want to use AddRow or AddStyledRow to create one.
 type Row struct {
 	cells []Cell
+
+	// parent is the index of the parent row for tree tables or TreeRoot
+	parent    int
+	collapsed bool
+
+	// key orders rows added concurrently with AddRowWithKey
+	key string
+
+	// summary replaces the cells with a single line spanning the table, such as the
+	// count of rows left out of a preview
+	summary string
 }
 
 // Col is a column of a table.  Use ColumnHeaders, ColumnStyles, etc. to adjust default
@@ -48,8 +82,12 @@ type Col struct {
 	naturalWidth  int
 	computedWidth int
 	wrap          bool
+	wrapMode      WrapMode
+	colorScale    *ColorScale
+	fixedWidth    int
 	style         *Style
 	justify       Justification
+	justifySet    bool
 }
 
 // Table is a table output to the console.  Use NewTable to construct the table with sensible defaults.
@@ -65,19 +103,67 @@ type Table struct {
 	maxHeight int
 	spacing   int
 
+	// limits set with MaxWidth and MaxHeight, applied each time the size is detected
+	widthLimit  int
+	heightLimit int
+	tabWidth    int
+	keyed       bool
+
+	subtitle        Title
+	caption         Title
+	footnotes       []string
+	footnoteStyle   *Style
+	titleJustify    Justification
+	subtitleJustify Justification
+	captionJustify  Justification
+	footnoteJustify Justification
+	titleSpacing    int
+
+	treeColumn    int
+	collapseDepth int
+	splitKeys     []int
+	inferTypes    bool
+	sortColumn    int
+	sortDesc      bool
+	rowNumbers    bool
+	head          int
+	tail          int
+	highlight     *regexp.Regexp
+	highlightSty  *Style
+	source        RowSource
+	sampleSize    int
+
 	writer io.Writer
+	mu     sync.RWMutex
+}
+
+// LayoutError is returned when none of the layout strategies are able to fit the
+// table inside the available width.  The overflow strategy currently accepts any
+// table, so layout never fails and this error is reserved for stricter strategies.
+type LayoutError struct {
+	// MaxWidth is the maximum width available to the table
+	MaxWidth int
+	// NaturalWidth is the width the table would need to render every column without wrapping
+	NaturalWidth int
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("no table rendering strategy suitable for natural width %d within max width %d", e.NaturalWidth, e.MaxWidth)
 }
 
 // TableOption is a function that sets an option on a table
 type TableOption func(t *Table) error
 
 // MaxHeight sets the table maximum height that can be used for pagination of
-// long tables.  Use this only when you want to reduce the maximum height of the table
-// to something less than the detected height of the terminal.  Normally you don't need to use
-// this and should prefer the auto detection.
+// long tables.  The actual max height will be set to the smaller of this number or the
+// detected height of the terminal.  Normally you don't need to use this and should prefer
+// the auto detection.
 func MaxHeight(h int) TableOption {
 	return func(t *Table) error {
-		t.maxHeight = h
+		if t.maxHeight > h {
+			t.maxHeight = h
+		}
+		t.heightLimit = h
 		return nil
 	}
 }
@@ -91,6 +177,7 @@ func MaxWidth(w int) TableOption {
 		if t.maxWidth > w {
 			t.maxWidth = w
 		}
+		t.widthLimit = w
 		return nil
 	}
 }
@@ -109,6 +196,15 @@ func Spacing(n int) TableOption {
 	}
 }
 
+// TabWidth sets the distance between tab stops used to expand tabs in cell values.  The
+// default is 8.
+func TabWidth(n int) TableOption {
+	return func(t *Table) error {
+		t.tabWidth = n
+		return nil
+	}
+}
+
 func (r *Row) addCell(c Cell) {
 	r.cells = append(r.cells, c)
 }
@@ -118,25 +214,67 @@ func (r *Row) addCell(c Cell) {
 // than available columns, the cells will be silently truncated.  If there are fewer values than columns,
 // the remaining columns will be empty.
 func (t *Table) AddRow(rowStrings ...string) *Table {
-	newRow := Row{}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows = append(t.rows, t.newRow(rowStrings))
+	return t
+}
+
+// AddStyledRow adds a new row to the table with custom styles for each Cell.  If you add more cells
+// than available columns, the cells will be silently truncated.  If there are fewer values than columns,
+// the remaining columns will be empty.
+func (t *Table) AddStyledRow(cells ...Cell) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows = append(t.rows, t.newStyledRow(cells))
+	return t
+}
+
+// AddRowWithKey is like AddRow but sets a key that determines the order of the row.  Rows are rendered
+// in key order, so rows added concurrently from many goroutines still produce the same output.  Rows
+// added without a key sort before all keyed rows.  SortBy takes precedence over the key.
+func (t *Table) AddRowWithKey(key string, rowStrings ...string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	newRow := t.newRow(rowStrings)
+	newRow.key = key
+	t.rows = append(t.rows, newRow)
+	t.keyed = true
+	return t
+}
+
+// AddStyledRowWithKey is like AddStyledRow but sets a key that determines the order of the row.  See
+// AddRowWithKey.
+func (t *Table) AddStyledRowWithKey(key string, cells ...Cell) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	newRow := t.newStyledRow(cells)
+	newRow.key = key
+	t.rows = append(t.rows, newRow)
+	t.keyed = true
+	return t
+}
+
+// newRow builds a row using the column styles, truncating or filling with empty cells
+// to match the number of columns
+func (t *Table) newRow(rowStrings []string) Row {
+	newRow := Row{parent: TreeRoot}
 	for i, rValue := range rowStrings {
 		if i >= len(t.columns) {
 			break
 		}
-		newRow.addCell(Cell{value: rValue, width: len(rValue), style: t.columns[i].style})
+		newRow.addCell(Cell{value: rValue, width: displayWidth(rValue), style: t.columns[i].style})
 	}
 	for len(newRow.cells) < len(t.columns) {
-		newRow.addCell(Cell{value: "", width: 0, style: Styled(Default)})
+		newRow.addCell(emptyCell())
 	}
-	t.rows = append(t.rows, newRow)
-	return t
+	return newRow
 }
 
-// AddStyledRow adds a new row to the table with custom styles for each Cell.  If you add more cells
-// than available columns, the cells will be silently truncated.  If there are fewer values than columns,
-// the remaining columns will be empty.
-func (t *Table) AddStyledRow(cells ...Cell) *Table {
-	newRow := Row{}
+// newStyledRow builds a row from custom cells, truncating or filling with empty cells
+// to match the number of columns
+func (t *Table) newStyledRow(cells []Cell) Row {
+	newRow := Row{parent: TreeRoot}
 	for i, cell1 := range cells {
 		if i >= len(t.columns) {
 			break
@@ -144,20 +282,25 @@ func (t *Table) AddStyledRow(cells ...Cell) *Table {
 		newRow.addCell(cell1)
 	}
 	for len(newRow.cells) < len(t.columns) {
-		newRow.addCell(Cell{value: "", width: 0, style: Styled(Default)})
+		newRow.addCell(emptyCell())
 	}
-	t.rows = append(t.rows, newRow)
-	return t
+	return newRow
+}
+
+func emptyCell() Cell {
+	return Cell{value: "", width: 0, style: Styled(Default)}
 }
 
 // StyledCell returns a new cell with a custom style for use with AddStyledRow
 func StyledCell(v string, sty *Style) Cell {
-	return Cell{value: v, width: len(v), style: sty}
+	return Cell{value: v, width: displayWidth(v), style: sty}
 }
 
 // ColumnStyles sets the default styles for each column in the row except
 // the column headers.
 func (t *Table) ColumnStyles(styles ...*Style) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for i, sty := range styles {
 		if i >= len(t.columns) {
 			return t
@@ -170,6 +313,8 @@ func (t *Table) ColumnStyles(styles ...*Style) *Table {
 // Title sets the title for the table.  The default style is bold, but can
 // be changed by passing your own styles
 func (t *Table) Title(s string, styles ...Styler) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	var sty *Style
 	switch {
 	case len(styles) > 0:
@@ -177,7 +322,7 @@ func (t *Table) Title(s string, styles ...Styler) *Table {
 	default:
 		sty = Styled(Bold)
 	}
-	t.title = Title{value: s, width: len(s), style: sty}
+	t.title = Title{value: s, width: displayWidth(s), style: sty}
 	return t
 }
 
@@ -185,19 +330,23 @@ func (t *Table) Title(s string, styles ...Styler) *Table {
 // The default style is Underline and Bold.  This can be changed through
 // a call to ColumnHeaderStyles.
 func (t *Table) ColumnHeaders(headers ...string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for i, header := range headers {
 		if i >= len(t.columns) {
 			return t
 		}
 		t.headers[i].value = header
 		t.headers[i].style = Styled(Bold, Underline)
-		t.headers[i].width = len(header)
+		t.headers[i].width = displayWidth(header)
 	}
 	return t
 }
 
 // ColumnHeaderStyles sets the column header styles.
 func (t *Table) ColumnHeaderStyles(styles ...*Style) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for i, style := range styles {
 		if i > len(t.columns) {
 			return t
@@ -210,11 +359,14 @@ func (t *Table) ColumnHeaderStyles(styles ...*Style) *Table {
 // Justification sets the justification of each column.  If you pass more justifications
 // than the number of columns they will be silently dropped.
 func (t *Table) Justification(cellJustifications ...Justification) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for i, just := range cellJustifications {
 		if i > len(t.columns) {
 			return t
 		}
 		t.columns[i].justify = just
+		t.columns[i].justifySet = true
 	}
 	return t
 }
@@ -223,11 +375,7 @@ func (t *Table) Justification(cellJustifications ...Justification) *Table {
 // justfication to left, and attempting to detect the existing terminal size to
 // set size defaults.
 func NewTable(numColumns int, options ...TableOption) *Table {
-	w, h, err := getTerminalSize()
-	if err != nil || w == 0 || h == 0 {
-		w = 80
-		h = 25
-	}
+	w, h := detectSize(os.Stdout)
 
 	// Fill with defaults to skip complicated bounds checking on
 	// changing justify or row styles
@@ -246,8 +394,21 @@ func NewTable(numColumns int, options ...TableOption) *Table {
 		maxHeight: h,
 		headers:   emptyHeaders,
 		pad:       1,
-		title:     Title{value: "", width: 0, style: Styled(Default)},
-		writer:    os.Stdout,
+		tabWidth:  8,
+
+		subtitle:        Title{style: Styled(Default)},
+		caption:         Title{style: Styled(Italic)},
+		footnoteStyle:   Styled(Dim),
+		titleJustify:    Center,
+		subtitleJustify: Center,
+		captionJustify:  Center,
+		footnoteJustify: Left,
+		titleSpacing:    1,
+		title:           Title{value: "", width: 0, style: Styled(Default)},
+		writer:          os.Stdout,
+
+		collapseDepth: -1,
+		sortColumn:    -1,
 	}
 	for _, opt := range options {
 		opt(t)
@@ -256,19 +417,28 @@ func NewTable(numColumns int, options ...TableOption) *Table {
 }
 
 // Show will render the table using the headers, title, and styles previously
-// set.
+// set.  Errors are ignored.  Use Render if you need to know whether the table
+// was written successfully.
 func (t *Table) Show() {
-	tableAsString := t.AsString()
-	fmt.Fprintf(t.writer, tableAsString)
-
+	t.mu.RLock()
+	w := t.writer
+	t.mu.RUnlock()
+	t.Render(w)
 }
 
 // ShowPage will render the table but pauses every n rows to paginate the output.
 // If n=0, it will use the detected terminal height to make sure that the number of rows
 // shown will fit in a single page.
 func (t *Table) ShowPage(n int) {
+	t.mu.RLock()
+	w, maxHeight, src := t.writer, t.maxHeight, t.source
+	t.mu.RUnlock()
 	if n == 0 {
-		n = t.maxHeight - 3
+		n = maxHeight - 3
+	}
+	if src != nil {
+		t.showSourcePage(n)
+		return
 	}
 	tableAsString := t.AsString()
 	lines := strings.SplitAfter(tableAsString, "\n")
@@ -278,39 +448,193 @@ func (t *Table) ShowPage(n int) {
 	for i := range lines {
 		switch {
 		case i > 0 && i%n == 0:
-			fmt.Fprintf(t.writer, lines[i])
+			io.WriteString(w, lines[i])
 			sess.PauseWithPrompt("\nResults %d-%d of %d. Press [Enter] to continue.\n", start, i+1, len(lines))
 			start = i + 2
 		default:
-			fmt.Fprintf(t.writer, lines[i])
+			io.WriteString(w, lines[i])
 		}
 	}
 }
 
-// SetWriter sets the output writer if not writing to Stdout
+// SetWriter sets the output writer if not writing to Stdout.  The size of the table is
+// detected again from the new writer.
 func (t *Table) SetWriter(w io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.writer = w
+	t.setSize(detectSize(w))
 }
 
-// AsString returns the rendered table as a string instead of immediately writing to the configured writer
-func (t *Table) AsString() string {
-	err := t.computeColWidths()
+// Render writes the table to w.  It returns any error from the writer or the row source.
+// Tables too wide to fit overflow the max width, so a *LayoutError is not returned today.
+func (t *Table) Render(w io.Writer) error {
+	if t.hasSource() {
+		return t.renderSource(w)
+	}
+	tableAsString, err := t.RenderString()
 	if err != nil {
-		// this error should never happen with fallback overflow strategy
-		log.Fatal(err)
+		return err
 	}
+	_, err = io.WriteString(w, tableAsString)
+	return err
+}
+
+// RenderString returns the rendered table as a string.  It returns any error from the row
+// source.  Tables too wide to fit overflow the max width, so a *LayoutError is not returned
+// today.
+func (t *Table) RenderString() (string, error) {
+	if t.hasSource() {
+		var out bytes.Buffer
+		err := t.renderSource(&out)
+		return out.String(), err
+	}
+	t.mu.RLock()
+	v := t.view()
+	t.mu.RUnlock()
+	return renderView(v)
+}
+
+// renderView lays out and renders a view of the table
+func renderView(v *Table) (string, error) {
+	if len(v.splitKeys) > 0 {
+		if panels := splitPanels(v); len(panels) > 1 {
+			return renderPanels(panels)
+		}
+	}
+	if err := v.computeColWidths(); err != nil {
+		return "", err
+	}
+	return renderTop(v) + renderBody(v) + renderBottom(v), nil
+}
+
+// renderBody renders the headers and rows of a table that has already been laid out
+func renderBody(t *Table) string {
 	var renderedT bytes.Buffer
-	renderedT.WriteString(renderTitle(t) + "\n\n")
 	renderedT.WriteString(renderHeaders(t.headers, t.columns, t.pad))
 	for _, row := range t.rows {
+		if len(row.summary) > 0 {
+			renderedT.WriteString(renderSummary(row.summary, t.pad, t.spacing))
+			continue
+		}
 		renderedT.WriteString(renderRow(row.cells, t.columns, t.pad, t.spacing))
 	}
 	return renderedT.String()
 }
 
+// hasSource returns true if the table renders from a RowSource
+func (t *Table) hasSource() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.source != nil
+}
+
+// view returns a copy of the table with the rows in display order, ready for layout and
+// rendering.  Layout mutates the columns of the view, leaving the original table untouched.
+func (t *Table) view() *Table {
+	v := t.derive()
+	v.columns = make([]Col, len(t.columns))
+	v.headers = make([]Cell, len(t.headers))
+	copy(v.columns, t.columns)
+	copy(v.headers, t.headers)
+	v.rows = t.displayRows()
+	normalizeCells(v)
+	if v.inferTypes {
+		inferJustification(v)
+	}
+	applyColorScales(v)
+	if v.highlight != nil {
+		findHighlights(v)
+	}
+	if v.rowNumbers {
+		addRowNumbers(v)
+	}
+	truncateRows(v)
+	return v
+}
+
+// normalizeCells expands tabs and measures multi-line values and renderers in the headers and rows
+func normalizeCells(t *Table) {
+	for i := range t.headers {
+		t.headers[i] = normalizeCell(t.headers[i], t.tabWidth)
+	}
+	for _, row := range t.rows {
+		for i := range row.cells {
+			row.cells[i] = normalizeCell(row.cells[i], t.tabWidth)
+		}
+	}
+}
+
+// derive returns a new table with the same settings as t but without any columns or rows
+func (t *Table) derive() *Table {
+	return &Table{
+		title:           t.title,
+		subtitle:        t.subtitle,
+		caption:         t.caption,
+		footnotes:       t.footnotes,
+		footnoteStyle:   t.footnoteStyle,
+		titleJustify:    t.titleJustify,
+		subtitleJustify: t.subtitleJustify,
+		captionJustify:  t.captionJustify,
+		footnoteJustify: t.footnoteJustify,
+		titleSpacing:    t.titleSpacing,
+		pad:             t.pad,
+		maxWidth:        t.maxWidth,
+		maxHeight:       t.maxHeight,
+		spacing:         t.spacing,
+		tabWidth:        t.tabWidth,
+		treeColumn:      t.treeColumn,
+		collapseDepth:   t.collapseDepth,
+		splitKeys:       t.splitKeys,
+		inferTypes:      t.inferTypes,
+		sortColumn:      t.sortColumn,
+		sortDesc:        t.sortDesc,
+		rowNumbers:      t.rowNumbers,
+		head:            t.head,
+		tail:            t.tail,
+		highlight:       t.highlight,
+		highlightSty:    t.highlightSty,
+		source:          t.source,
+		sampleSize:      t.sampleSize,
+		writer:          t.writer,
+	}
+}
+
+// displayRows returns copies of the rows in the order they should be rendered
+func (t *Table) displayRows() []Row {
+	if t.isTree() {
+		return treeRows(t)
+	}
+	order := make([]int, len(t.rows))
+	for i := range order {
+		order[i] = i
+	}
+	t.sortRows(order)
+	rows := make([]Row, len(t.rows))
+	for n, i := range order {
+		rows[n] = t.rows[i].clone()
+	}
+	return rows
+}
+
+// clone returns a copy of the row that does not share cells with the original
+func (r Row) clone() Row {
+	cells := make([]Cell, len(r.cells))
+	copy(cells, r.cells)
+	r.cells = cells
+	return r
+}
+
+// AsString returns the rendered table as a string instead of immediately writing to the configured writer.
+// It drops any rendering error.  Use RenderString to get the error.
+func (t *Table) AsString() string {
+	tableAsString, _ := t.RenderString()
+	return tableAsString
+}
+
 // renderTitle returns the title as a formatted string
 func renderTitle(t *Table) string {
-	return justCenter(t.title.value, t.width(), 0, t.title.style)
+	return renderCell(t.title.value, t.width(), 0, t.title.style, t.titleJustify)
 }
 
 // renders the headers as a string
@@ -318,14 +642,14 @@ func renderHeaders(cells []Cell, cols []Col, pad int) string {
 	wrappedLinesCount := make([]int, len(cells))
 
 	for i, cell1 := range cells {
-		wrappedL := wrap(cell1.value, cols[i].computedWidth)
+		wrappedL := cellLines(cell1, cols[i], cols[i].computedWidth)
 		wrappedLinesCount[i] = len(wrappedL)
 	}
 	_, totalLines := max(wrappedLinesCount)
 	lines := make([]bytes.Buffer, totalLines)
 
 	for cellN, cellV := range cells {
-		wL := wrap(cellV.value, cols[cellN].computedWidth)
+		wL := cellLines(cellV, cols[cellN], cols[cellN].computedWidth)
 		for i := 0; i < totalLines; i++ {
 			switch {
 			case i < len(wL):
@@ -349,7 +673,7 @@ func renderRow(cells []Cell, cols []Col, pad int, spacing int) string {
 	wrappedLinesCount := make([]int, len(cells))
 
 	for i, cell1 := range cells {
-		wrappedL := wrap(cell1.value, cols[i].computedWidth)
+		wrappedL := cellLines(cell1, cols[i], textWidth(cell1, cols[i]))
 		wrappedLinesCount[i] = len(wrappedL)
 	}
 	_, totalLines := max(wrappedLinesCount)
@@ -365,7 +689,18 @@ func renderRow(cells []Cell, cols []Col, pad int, spacing int) string {
 			sty = cols[cellN].style
 		}
 
-		wL := wrap(cellV.value, cols[cellN].computedWidth)
+		if len(cellV.prefix) > 0 || len(cellV.indent) > 0 {
+			renderIndentedCell(lines, cellV, sty, cols[cellN], pad)
+			continue
+		}
+
+		var wL []string
+		switch {
+		case len(cellV.highlights) > 0:
+			wL = highlightLines(cellV, cols[cellN], cols[cellN].computedWidth, sty)
+		default:
+			wL = cellLines(cellV, cols[cellN], cols[cellN].computedWidth)
+		}
 		for i := 0; i < totalLines; i++ {
 			switch {
 			case i < len(wL):
@@ -386,6 +721,51 @@ func renderRow(cells []Cell, cols []Col, pad int, spacing int) string {
 	return out.String()
 }
 
+// renderIndentedCell renders a cell with a prefix on the first line and an indent on every
+// following line.  The prefix and indent are left unstyled and are not affected by wrapping.
+func renderIndentedCell(lines []bytes.Buffer, c Cell, sty *Style, col Col, pad int) {
+	prefixW := displayWidth(c.prefix)
+	textW := textWidth(c, col)
+	var wL []string
+	switch {
+	case len(c.highlights) > 0:
+		wL = highlightLines(c, col, textW, sty)
+	default:
+		wL = cellLines(c, col, textW)
+	}
+	for i := range lines {
+		var guide, text string
+		switch {
+		case i == 0:
+			guide = c.prefix
+		default:
+			guide = c.indent
+		}
+		if i < len(wL) {
+			text = wL[i]
+		}
+		lines[i].WriteString(spaces(pad) + guide + spaces(prefixW-displayWidth(guide)))
+		lines[i].WriteString(renderCell(text, textW, 0, sty, col.justify) + spaces(pad))
+	}
+}
+
+// cellLines returns the lines of the cell contents at width w
+func cellLines(c Cell, col Col, w int) []string {
+	if c.renderer != nil {
+		return c.renderer.lines(w)
+	}
+	return wrapWith(c.value, w, col.wrapMode)
+}
+
+// textWidth is the width available for the cell value after making room for its prefix
+func textWidth(c Cell, col Col) int {
+	w := col.computedWidth - displayWidth(c.prefix)
+	if w < 1 {
+		return 1
+	}
+	return w
+}
+
 // renderCell renders the cell as a string using the correct justification
 func renderCell(s string, width int, pad int, sty *Style, justify Justification) string {
 	switch justify {
@@ -401,7 +781,7 @@ func renderCell(s string, width int, pad int, sty *Style, justify Justification)
 
 // justCenter is center-justified text with padding and style
 func justCenter(s string, width int, pad int, sty *Style) string {
-	contentLen := len(s)
+	contentLen := displayWidth(s)
 	onLeft := (width - contentLen) / 2
 	if onLeft < 0 {
 		onLeft = 0
@@ -420,7 +800,7 @@ func justCenter(s string, width int, pad int, sty *Style) string {
 
 // justLeft is left-justified text with padding and style
 func justLeft(s string, width int, pad int, sty *Style) string {
-	contentLen := len(s)
+	contentLen := displayWidth(s)
 	onRight := width - contentLen
 	if onRight < 0 {
 		onRight = 0
@@ -435,7 +815,7 @@ func justLeft(s string, width int, pad int, sty *Style) string {
 
 // justRight is right-justified text with padding and style
 func justRight(s string, width int, pad int, sty *Style) string {
-	contentLen := len(s)
+	contentLen := displayWidth(s)
 	onLeft := width - contentLen
 	if onLeft < 0 {
 		onLeft = 0
@@ -449,35 +829,106 @@ func justRight(s string, width int, pad int, sty *Style) string {
 
 }
 
-// wrap will break long lines on breakpoints space, :, ., /, \, -.  If
-// line is too long without breakpoints, will do dumb wrap at width w.
-func wrap(s string, w int) []string {
-	var out []string
-	var wrapped string
-	rem := s
-	for len(rem) > 0 {
-		wrapped, rem = wrapSubString(rem, w, " :.-/\\")
-		out = append(out, wrapped)
+// expandTabs replaces tabs with spaces up to the next tab stop.  Tab stops are
+// counted from the start of each line.
+func expandTabs(s string, tabWidth int) string {
+	if !strings.Contains(s, "\t") {
+		return s
 	}
-	return out
+	if tabWidth < 1 {
+		tabWidth = 1
+	}
+	var out strings.Builder
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := tabWidth - col%tabWidth
+			out.WriteString(spaces(n))
+			col += n
+		case '\n':
+			out.WriteRune(r)
+			col = 0
+		default:
+			out.WriteRune(r)
+			col++
+		}
+	}
+	return out.String()
 }
 
-// wrapSubString - don't call directly. Works with wrap to recursively
-// split a string at the specified breakpoints.
-func wrapSubString(s string, w int, breakpts string) (wrapped string, remainder string) {
+// normalizeCell expands tabs in the cell value and sets the width to the width of its widest line,
+// or to the natural width of its renderer
+func normalizeCell(c Cell, tabWidth int) Cell {
+	if c.renderer != nil {
+		// renderers such as nested tables can change after the cell is created
+		c.width = c.renderer.naturalWidth()
+		return c
+	}
+	if !strings.ContainsAny(c.value, "\t\n") {
+		return c
+	}
+	c.value = expandTabs(strings.TrimRight(c.value, "\r\n"), tabWidth)
+	c.width = 0
+	for _, line := range strings.Split(c.value, "\n") {
+		if w := displayWidth(strings.TrimRight(line, "\r")); w > c.width {
+			c.width = w
+		}
+	}
+	return c
+}
 
-	if len(s) <= w {
-		return strings.TrimSpace(s), ""
+// displayWidth returns the number of terminal cells used to display s
+func displayWidth(s string) int {
+	return utf8.RuneCountInString(stripEscapes(s))
+}
+
+// stripEscapes removes ANSI control sequences (CSI) and operating system commands (OSC)
+// so that styled text can be measured
+func stripEscapes(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\x1b' {
+			out.WriteByte(s[i])
+			i++
+			continue
+		}
+		i += escapeLen(s[i:])
 	}
+	return out.String()
+}
 
-	ind := strings.LastIndexAny(s[0:w], breakpts)
-	switch {
-	case ind > 0:
-		return strings.TrimSpace(s[0 : ind+1]), strings.TrimSpace(s[ind+1 : len(s)])
-	case ind == -1:
-		return strings.TrimSpace(s[0:w]), strings.TrimSpace(s[w:len(s)])
+// escapeLen returns the length of the escape sequence at the start of s
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
 	}
-	return "", ""
+	i := 2
+	switch s[1] {
+	case '[':
+		// CSI sequences end with a byte in the range @ to ~
+		for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+			i++
+		}
+		if i < len(s) {
+			i++
+		}
+	case ']':
+		// OSC sequences end with BEL or ESC \
+		for i < len(s) && s[i] != '\a' && !(s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\') {
+			i++
+		}
+		switch {
+		case i < len(s) && s[i] == '\a':
+			i++
+		case i < len(s):
+			i += 2
+		}
+	}
+	return i
 }
 
 // spaces is a convenience function to get n spaces repeated
@@ -503,7 +954,7 @@ func (t *Table) computeColWidths() error {
 	case overflowStrategy(t):
 		return nil
 	}
-	return fmt.Errorf("no table rendering strategy suitable")
+	return &LayoutError{MaxWidth: t.maxWidth, NaturalWidth: sum(mapAdd(extractNatWidth(t), 2*t.pad))}
 }
 
 // simpleStrategy sets all column widths to their natural width.
@@ -580,8 +1031,8 @@ func computeNaturalWidths(t *Table) {
 
 	for _, row := range t.rows {
 		for col, cell := range row.cells {
-			if cell.width > maxColW[col] {
-				maxColW[col] = cell.width
+			if w := cell.width + displayWidth(cell.prefix); w > maxColW[col] {
+				maxColW[col] = w
 			}
 		}
 	}
@@ -593,7 +1044,12 @@ func computeNaturalWidths(t *Table) {
 	}
 
 	for i, natWidth := range maxColW {
-		t.columns[i].naturalWidth = natWidth
+		switch {
+		case t.columns[i].fixedWidth > 0:
+			t.columns[i].naturalWidth = t.columns[i].fixedWidth
+		default:
+			t.columns[i].naturalWidth = natWidth
+		}
 	}
 }
 
@@ -0,0 +1,138 @@
+package clt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Subtitle sets a line rendered below the title.  The default style is the default
+// terminal color, but can be changed by passing your own styles.
+func (t *Table) Subtitle(s string, styles ...Styler) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.subtitle = Title{value: s, width: displayWidth(s), style: t.subtitle.style}
+	if len(styles) > 0 {
+		t.subtitle.style = Styled(styles...)
+	}
+	return t
+}
+
+// Caption sets text rendered below the table.  The default style is italic, but can be
+// changed by passing your own styles.
+func (t *Table) Caption(s string, styles ...Styler) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.caption = Title{value: s, width: displayWidth(s), style: t.caption.style}
+	if len(styles) > 0 {
+		t.caption.style = Styled(styles...)
+	}
+	return t
+}
+
+// Footnote adds a numbered footnote rendered below the table and returns its reference
+// marker, such as [1], to add to the cells that refer to it.
+func (t *Table) Footnote(s string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.footnotes = append(t.footnotes, s)
+	return footnoteMarker(len(t.footnotes))
+}
+
+// FootnoteStyle sets the style of the footnotes.  The default style is dim.
+func (t *Table) FootnoteStyle(styles ...Styler) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.footnoteStyle = Styled(styles...)
+	return t
+}
+
+// TitleJustification sets the placement of the title.  The default is centered.
+func (t *Table) TitleJustification(j Justification) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.titleJustify = j
+	return t
+}
+
+// SubtitleJustification sets the placement of the subtitle.  The default is centered.
+func (t *Table) SubtitleJustification(j Justification) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.subtitleJustify = j
+	return t
+}
+
+// CaptionJustification sets the placement of the caption.  The default is centered.
+func (t *Table) CaptionJustification(j Justification) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.captionJustify = j
+	return t
+}
+
+// FootnoteJustification sets the placement of the footnotes.  The default is left.
+func (t *Table) FootnoteJustification(j Justification) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.footnoteJustify = j
+	return t
+}
+
+// TitleSpacing sets the number of blank lines between the title and the column headers.
+// The default is 1.  For n=0 the headers follow the title directly, and a table without
+// a title starts with its headers.
+func TitleSpacing(n int) TableOption {
+	return func(t *Table) error {
+		if n < 0 {
+			n = 0
+		}
+		t.titleSpacing = n
+		return nil
+	}
+}
+
+func footnoteMarker(n int) string {
+	return fmt.Sprintf("[%d]", n)
+}
+
+// renderTop renders the title, subtitle and the blank lines that separate them from
+// the headers
+func renderTop(t *Table) string {
+	var out bytes.Buffer
+	if len(t.title.value) > 0 || t.titleSpacing > 0 {
+		out.WriteString(renderTitle(t) + "\n")
+	}
+	if len(t.subtitle.value) > 0 {
+		out.WriteString(renderText(t.subtitle.value, t.width(), t.subtitle.style, t.subtitleJustify))
+	}
+	out.WriteString(strings.Repeat("\n", t.titleSpacing))
+	return out.String()
+}
+
+// renderBottom renders the caption and footnotes below the table, separated from it by a
+// blank line
+func renderBottom(t *Table) string {
+	if len(t.caption.value) == 0 && len(t.footnotes) == 0 {
+		return ""
+	}
+	var out bytes.Buffer
+	out.WriteString("\n")
+	if len(t.caption.value) > 0 {
+		out.WriteString(renderText(t.caption.value, t.width(), t.caption.style, t.captionJustify))
+	}
+	for i, note := range t.footnotes {
+		out.WriteString(renderText(footnoteMarker(i+1)+" "+note, t.width(), t.footnoteStyle, t.footnoteJustify))
+	}
+	return out.String()
+}
+
+// renderText wraps s to the width of the table and renders each line with the given
+// style and justification
+func renderText(s string, width int, sty *Style, justify Justification) string {
+	var out bytes.Buffer
+	for _, line := range wrap(s, width) {
+		out.WriteString(strings.TrimRight(renderCell(line, width, 0, sty, justify), " ") + "\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,48 @@
+package clt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitleSpacing(t *testing.T) {
+	table := NewTable(1, TitleSpacing(0))
+	table.AddRow("a")
+	out, err := table.RenderString()
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSuffix(out, "\n"), "\n"), 1)
+
+	table = NewTable(1)
+	table.AddRow("a")
+	out, _ = table.RenderString()
+	assert.Len(t, strings.Split(strings.TrimSuffix(out, "\n"), "\n"), 3)
+}
+
+func TestCaptionFootnotes(t *testing.T) {
+	table := NewTable(1)
+	table.Title("Title")
+	table.Subtitle("sub", Default)
+	table.Caption("cap", Default).CaptionJustification(Left)
+	table.FootnoteStyle(Default)
+	ref := table.Footnote("note")
+	assert.Equal(t, "[1]", ref)
+	table.AddRow("value" + ref)
+	out, err := table.RenderString()
+	assert.NoError(t, err)
+	lines := strings.Split(out, "\n")
+	assert.Contains(t, lines[1], "sub")
+	assert.Equal(t, "", lines[2])
+	assert.Contains(t, out, "\n\n"+Styled(Default).ApplyTo("cap"))
+	assert.Contains(t, out, Styled(Default).ApplyTo("[1] note")+"\n")
+}
+
+func TestTitleJustification(t *testing.T) {
+	table := NewTable(1)
+	table.Title("T").TitleJustification(Left)
+	table.AddRow("abcdefghij")
+	v := table.view()
+	v.computeColWidths()
+	assert.True(t, strings.HasPrefix(renderTitle(v), Styled(Bold).ApplyTo("T")), renderTitle(v))
+}
@@ -0,0 +1,123 @@
+package clt
+
+import (
+	"math"
+	"strings"
+)
+
+// barWidth is the natural width of a bar cell.  Bars grow or shrink to the computed
+// column width.
+const barWidth = 10
+
+var (
+	// eighth blocks used for the partially filled end of a bar, indexed by eighths filled
+	barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+	barFull    = "█"
+	// sparkline levels from lowest to highest
+	sparkLevels = []rune("▁▂▃▄▅▆▇█")
+)
+
+// barGraph draws a value as a horizontal bar scaled to the column width
+type barGraph struct {
+	value float64
+	max   float64
+}
+
+// BarCell returns a cell that draws value as a horizontal bar whose length is value/max of the
+// column width.  Use it with AddStyledRow.  The bar is drawn using eighth-block characters so that
+// small differences remain visible in narrow columns.
+func BarCell(value float64, max float64, sty *Style) Cell {
+	return rendererCell(barGraph{value: value, max: max}, sty)
+}
+
+func (b barGraph) naturalWidth() int {
+	return barWidth
+}
+
+func (b barGraph) lines(w int) []string {
+	frac := 0.0
+	if b.max > 0 {
+		frac = b.value / b.max
+	}
+	switch {
+	case math.IsNaN(frac) || frac < 0:
+		frac = 0
+	case frac > 1:
+		frac = 1
+	}
+	eighths := int(math.Round(frac * float64(w*8)))
+	return []string{strings.Repeat(barFull, eighths/8) + barEighths[eighths%8]}
+}
+
+// sparkline draws a series of values using block characters of increasing height
+type sparkline struct {
+	values []float64
+}
+
+// SparklineCell returns a cell that draws values as a sparkline with one character per value.  Use it
+// with AddStyledRow.  If the column is narrower than the number of values, neighboring values are
+// averaged so the whole series still fits.  NaN and negative infinity are drawn at the lowest level
+// and positive infinity at the highest.
+func SparklineCell(values []float64, sty *Style) Cell {
+	return rendererCell(sparkline{values: values}, sty)
+}
+
+func (s sparkline) naturalWidth() int {
+	return len(s.values)
+}
+
+func (s sparkline) lines(w int) []string {
+	values := resample(s.values, w)
+	if len(values) == 0 {
+		return nil
+	}
+	// the scale comes from the finite values so that NaN and infinities can't stretch it
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	out := make([]rune, len(values))
+	for i, v := range values {
+		frac := 0.0
+		switch {
+		case math.IsInf(v, 1):
+			frac = 1
+		case hi > lo:
+			frac = (v - lo) / (hi - lo)
+		}
+		switch {
+		case math.IsNaN(frac) || frac < 0:
+			frac = 0
+		case frac > 1:
+			frac = 1
+		}
+		out[i] = sparkLevels[int(math.Round(frac*float64(len(sparkLevels)-1)))]
+	}
+	return []string{string(out)}
+}
+
+// resample averages values into w buckets when there are more than w values
+func resample(values []float64, w int) []float64 {
+	if len(values) <= w || w < 1 {
+		return values
+	}
+	out := make([]float64, w)
+	for i := range out {
+		start := i * len(values) / w
+		end := (i + 1) * len(values) / w
+		total := 0.0
+		for _, v := range values[start:end] {
+			total += v
+		}
+		out[i] = total / float64(end-start)
+	}
+	return out
+}
+
+// rendererCell returns a cell drawn by r at the computed column width
+func rendererCell(r cellRenderer, sty *Style) Cell {
+	return Cell{width: r.naturalWidth(), style: sty, renderer: r}
+}
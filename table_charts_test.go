@@ -0,0 +1,57 @@
+package clt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBarCell(t *testing.T) {
+	tt := []struct {
+		Name  string
+		Value float64
+		Max   float64
+		Width int
+		Want  string
+	}{
+		{Name: "half", Value: 50, Max: 100, Width: 4, Want: "██"},
+		{Name: "one eighth", Value: 1, Max: 16, Width: 2, Want: "▏"},
+		{Name: "partial block", Value: 5, Max: 8, Width: 2, Want: "█▎"},
+		{Name: "clamped", Value: 200, Max: 100, Width: 3, Want: "███"},
+		{Name: "zero max", Value: 10, Max: 0, Width: 3, Want: ""},
+	}
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			c := BarCell(tc.Value, tc.Max, nil)
+			assert.Equal(t, barWidth, c.width)
+			assert.Equal(t, []string{tc.Want}, c.renderer.lines(tc.Width))
+		})
+	}
+}
+
+func TestSparklineCell(t *testing.T) {
+	c := SparklineCell([]float64{0, 1, 2, 3, 4, 5, 6, 7}, nil)
+	assert.Equal(t, 8, c.width)
+	assert.Equal(t, []string{"▁▂▃▄▅▆▇█"}, c.renderer.lines(8))
+	assert.Equal(t, []string{"▁▃▆█"}, c.renderer.lines(4))
+	assert.Equal(t, []string{"▁▁"}, SparklineCell([]float64{3, 3}, nil).renderer.lines(10))
+}
+
+func TestSparklineNonFinite(t *testing.T) {
+	values := []float64{0, math.NaN(), 7, math.Inf(1), math.Inf(-1)}
+	assert.Equal(t, []string{"▁▁██▁"}, SparklineCell(values, nil).renderer.lines(5))
+	assert.Equal(t, []string{"▁█"}, SparklineCell([]float64{math.NaN(), math.Inf(1)}, nil).renderer.lines(2))
+	assert.Equal(t, []string{"▁▁"}, SparklineCell(values, nil).renderer.lines(2))
+}
+
+func TestChartCellsInTable(t *testing.T) {
+	table := NewTable(2)
+	table.pad = 0
+	table.maxWidth = 80
+	table.AddStyledRow(StyledCell("cpu", nil), BarCell(1, 1, nil))
+	v := table.view()
+	v.computeColWidths()
+	assert.Equal(t, []int{3, barWidth}, extractComputedWidth(v))
+	assert.Equal(t, "cpu██████████\n", renderRow(v.rows[0].cells, v.columns, v.pad, v.spacing))
+}
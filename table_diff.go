@@ -0,0 +1,122 @@
+package clt
+
+// Markers shown in the first column of a diff table
+const (
+	diffAdded     = "+"
+	diffRemoved   = "-"
+	diffChanged   = "~"
+	diffUnchanged = " "
+)
+
+// DiffTables compares two tables whose rows are identified by the value in column key and returns
+// a new table showing what changed.  Rows only in after are marked + in green, rows only in before
+// are marked - in red, and rows in both with different values are marked ~ with each changed cell
+// shown as "old → new" in yellow.  Removed rows are shown near their original position.  The
+// headers, title and justification are taken from after.  If one of the tables has no column key,
+// its rows have empty keys.
+func DiffTables(before, after *Table, key int) *Table {
+	before.mu.RLock()
+	defer before.mu.RUnlock()
+	if before != after {
+		after.mu.RLock()
+		defer after.mu.RUnlock()
+	}
+
+	numCols := len(after.columns)
+	diff := NewTable(numCols + 1)
+	diff.title = after.title
+	diff.headers[0] = Cell{value: "", style: Styled(Bold)}
+	copy(diff.headers[1:], after.headers)
+	for i, col := range after.columns {
+		diff.columns[i+1].justify = col.justify
+		diff.columns[i+1].justifySet = col.justifySet
+		diff.columns[i+1].style = col.style
+	}
+	if key < 0 || key >= numCols {
+		return diff
+	}
+
+	beforeIndex := make(map[string]int)
+	for i, row := range before.rows {
+		k := cellValue(row.cells, key)
+		if _, ok := beforeIndex[k]; !ok {
+			beforeIndex[k] = i
+		}
+	}
+	afterKeys := make(map[string]bool)
+	for _, row := range after.rows {
+		afterKeys[cellValue(row.cells, key)] = true
+	}
+
+	green, red, yellow := Styled(Green), Styled(Red), Styled(Yellow)
+	removed := func(rows []Row) {
+		for _, row := range rows {
+			if afterKeys[cellValue(row.cells, key)] {
+				continue
+			}
+			diff.rows = append(diff.rows, diffRow(diffRemoved, row.cells, numCols, red))
+		}
+	}
+
+	next := 0
+	for _, row := range after.rows {
+		j, ok := beforeIndex[cellValue(row.cells, key)]
+		if !ok {
+			diff.rows = append(diff.rows, diffRow(diffAdded, row.cells, numCols, green))
+			continue
+		}
+		if j >= next {
+			removed(before.rows[next:j])
+			next = j + 1
+		}
+		diff.rows = append(diff.rows, changedRow(before.rows[j].cells, row.cells, numCols, yellow))
+	}
+	removed(before.rows[next:])
+	return diff
+}
+
+// cellValue returns the value of cell i, or an empty value if the row has fewer cells, such as
+// when a column was added to one of the tables
+func cellValue(cells []Cell, i int) string {
+	if i < len(cells) {
+		return cells[i].value
+	}
+	return ""
+}
+
+// diffRow returns a row with every cell in the same style
+func diffRow(marker string, cells []Cell, numCols int, sty *Style) Row {
+	row := Row{parent: TreeRoot}
+	row.addCell(StyledCell(marker, sty))
+	for i := 0; i < numCols; i++ {
+		c := emptyCell()
+		if i < len(cells) {
+			c = StyledCell(cells[i].value, sty)
+		}
+		row.addCell(c)
+	}
+	return row
+}
+
+// changedRow returns a row showing each changed cell as old → new, or the unchanged row
+func changedRow(oldCells []Cell, newCells []Cell, numCols int, sty *Style) Row {
+	row := Row{parent: TreeRoot}
+	row.addCell(Cell{value: diffUnchanged, width: 1, style: Styled(Default)})
+	for i := 0; i < numCols; i++ {
+		var oldV, newV string
+		if i < len(oldCells) {
+			oldV = oldCells[i].value
+		}
+		c := emptyCell()
+		if i < len(newCells) {
+			c = newCells[i]
+			newV = newCells[i].value
+		}
+		if oldV != newV {
+			c = StyledCell(oldV+" → "+newV, sty)
+			row.cells[0] = StyledCell(diffChanged, sty)
+		}
+		row.addCell(c)
+	}
+	return row
+}
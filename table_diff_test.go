@@ -0,0 +1,65 @@
+package clt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTables(t *testing.T) {
+	before := NewTable(2).ColumnHeaders("Name", "Size")
+	before.AddRow("a", "1")
+	before.AddRow("b", "2")
+	before.AddRow("c", "3")
+
+	after := NewTable(2).ColumnHeaders("Name", "Size")
+	after.AddRow("a", "1")
+	after.AddRow("c", "4")
+	after.AddRow("d", "5")
+
+	diff := DiffTables(before, after, 0)
+	assert.Equal(t, "Name", diff.headers[1].value)
+
+	var got [][]string
+	for i := range diff.rows {
+		got = append(got, rowValues(diff, i))
+	}
+	assert.Equal(t, [][]string{
+		{" ", "a", "1"},
+		{"-", "b", "2"},
+		{"~", "c", "3 → 4"},
+		{"+", "d", "5"},
+	}, got)
+
+	assert.Equal(t, Styled(Red), diff.rows[1].cells[1].style)
+	assert.Equal(t, Styled(Yellow), diff.rows[2].cells[2].style)
+	assert.Equal(t, Styled(Green), diff.rows[3].cells[0].style)
+	assert.Equal(t, 5, diff.rows[2].cells[2].width)
+}
+
+func TestDiffTablesRemovedAtEnd(t *testing.T) {
+	before := NewTable(1)
+	before.AddRow("a")
+	before.AddRow("b")
+	after := NewTable(1)
+	after.AddRow("a")
+
+	diff := DiffTables(before, after, 0)
+	assert.Equal(t, []string{"-", "b"}, rowValues(diff, 1))
+	assert.Equal(t, 2, diff.NumRows())
+}
+
+func TestDiffTablesAddedColumn(t *testing.T) {
+	before := NewTable(1)
+	before.AddRow("a")
+	after := NewTable(2)
+	after.AddRow("a", "x")
+
+	diff := DiffTables(before, after, 1)
+	assert.Equal(t, []string{"+", "a", "x"}, rowValues(diff, 0))
+	assert.Equal(t, []string{"-", "a", ""}, rowValues(diff, 1))
+
+	diff = DiffTables(before, after, 0)
+	assert.Equal(t, []string{"~", "a", " → x"}, rowValues(diff, 0))
+	assert.Equal(t, 1, diff.NumRows())
+}
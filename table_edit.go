@@ -0,0 +1,222 @@
+package clt
+
+// NumColumns returns the number of columns in the table
+func (t *Table) NumColumns() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.columns)
+}
+
+// NumRows returns the number of rows in the table, including rows hidden by collapsing a tree
+func (t *Table) NumRows() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.rows)
+}
+
+// AddColumn adds a new column at the end of the table with the given header.  Existing rows
+// get an empty cell in the new column.
+func (t *Table) AddColumn(header string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.insertColumn(len(t.columns), header)
+}
+
+// InsertColumn inserts a new column before column i with the given header.  If i is past the
+// last column, the column is added at the end.  Existing rows get an empty cell in the new column.
+func (t *Table) InsertColumn(i int, header string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.insertColumn(i, header)
+}
+
+func (t *Table) insertColumn(i int, header string) *Table {
+	if i < 0 {
+		i = 0
+	}
+	if i > len(t.columns) {
+		i = len(t.columns)
+	}
+
+	col := Col{style: Styled(Default), justify: Left}
+	t.columns = append(t.columns, Col{})
+	copy(t.columns[i+1:], t.columns[i:])
+	t.columns[i] = col
+	for n := range t.columns {
+		t.columns[n].index = n
+	}
+
+	var h Cell
+	if len(header) > 0 {
+		h = Cell{value: header, width: displayWidth(header), style: Styled(Bold, Underline)}
+	}
+	t.headers = insertCell(t.headers, i, h)
+	for r := range t.rows {
+		t.rows[r].cells = insertCell(t.rows[r].cells, i, emptyCell())
+	}
+
+	shift := func(n int) int {
+		if n >= i {
+			return n + 1
+		}
+		return n
+	}
+	if len(t.columns) > 1 {
+		t.treeColumn = shift(t.treeColumn)
+	}
+	if t.sortColumn >= 0 {
+		t.sortColumn = shift(t.sortColumn)
+	}
+	var keys []int
+	for _, key := range t.splitKeys {
+		keys = append(keys, shift(key))
+	}
+	t.splitKeys = keys
+	return t
+}
+
+// RemoveColumn removes column i and its cells from every row
+func (t *Table) RemoveColumn(i int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i < 0 || i >= len(t.columns) {
+		return t
+	}
+	t.columns = append(t.columns[:i], t.columns[i+1:]...)
+	for n := range t.columns {
+		t.columns[n].index = n
+	}
+	t.headers = append(t.headers[:i], t.headers[i+1:]...)
+	for r := range t.rows {
+		t.rows[r].cells = append(t.rows[r].cells[:i], t.rows[r].cells[i+1:]...)
+	}
+
+	switch {
+	case t.treeColumn == i:
+		t.treeColumn = 0
+	case t.treeColumn > i:
+		t.treeColumn--
+	}
+	switch {
+	case t.sortColumn == i:
+		t.sortColumn = -1
+	case t.sortColumn > i:
+		t.sortColumn--
+	}
+	var keys []int
+	for _, key := range t.splitKeys {
+		switch {
+		case key == i:
+		case key > i:
+			keys = append(keys, key-1)
+		default:
+			keys = append(keys, key)
+		}
+	}
+	t.splitKeys = keys
+	return t
+}
+
+// UpdateCell replaces the value of the cell at row, col keeping its current style
+func (t *Table) UpdateCell(row, col int, value string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.inBounds(row, col) {
+		return t
+	}
+	c := &t.rows[row].cells[col]
+	c.value = value
+	c.width = displayWidth(value)
+	return t
+}
+
+// UpdateStyledCell replaces the cell at row, col
+func (t *Table) UpdateStyledCell(row, col int, c Cell) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.inBounds(row, col) {
+		return t
+	}
+	t.rows[row].cells[col] = c
+	return t
+}
+
+// SetRow replaces the contents of the row at index row.  The row keeps its position
+// in a tree.  Like AddRow, extra values are dropped and missing values are left empty.
+func (t *Table) SetRow(row int, rowStrings ...string) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if row < 0 || row >= len(t.rows) {
+		return t
+	}
+	t.rows[row].cells = t.newRow(rowStrings).cells
+	return t
+}
+
+// SetStyledRow replaces the cells of the row at index row
+func (t *Table) SetStyledRow(row int, cells ...Cell) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if row < 0 || row >= len(t.rows) {
+		return t
+	}
+	t.rows[row].cells = t.newStyledRow(cells).cells
+	return t
+}
+
+// DeleteRows deletes the rows at the given indexes.  Deleting a row in a tree also deletes
+// all of its descendants.  The indexes of the remaining rows are renumbered in order.
+func (t *Table) DeleteRows(rows ...int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	deleted := make([]bool, len(t.rows))
+	for _, row := range rows {
+		if row >= 0 && row < len(t.rows) {
+			deleted[row] = true
+		}
+	}
+	// parents always come before their children so a single pass finds all descendants
+	for i, row := range t.rows {
+		if row.parent >= 0 && row.parent < len(deleted) && deleted[row.parent] {
+			deleted[i] = true
+		}
+	}
+
+	newIndex := make([]int, len(t.rows))
+	var kept []Row
+	for i, row := range t.rows {
+		if deleted[i] {
+			newIndex[i] = TreeRoot
+			continue
+		}
+		newIndex[i] = len(kept)
+		kept = append(kept, row)
+	}
+	for i := range kept {
+		if kept[i].parent >= 0 && kept[i].parent < len(newIndex) {
+			kept[i].parent = newIndex[kept[i].parent]
+		}
+	}
+	t.rows = kept
+	return t
+}
+
+// ClearRows deletes all rows, keeping the columns, headers and styles
+func (t *Table) ClearRows() *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows = nil
+	return t
+}
+
+func (t *Table) inBounds(row, col int) bool {
+	return row >= 0 && row < len(t.rows) && col >= 0 && col < len(t.columns)
+}
+
+// insertCell inserts c into cells before index i
+func insertCell(cells []Cell, i int, c Cell) []Cell {
+	cells = append(cells, Cell{})
+	copy(cells[i+1:], cells[i:])
+	cells[i] = c
+	return cells
+}
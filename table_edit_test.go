@@ -0,0 +1,88 @@
+package clt
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rowValues(t *Table, row int) []string {
+	var out []string
+	for _, c := range t.rows[row].cells {
+		out = append(out, c.value)
+	}
+	return out
+}
+
+func TestAddInsertRemoveColumn(t *testing.T) {
+	table := NewTable(2).ColumnHeaders("A", "B")
+	table.AddRow("a", "b")
+
+	table.AddColumn("D")
+	assert.Equal(t, 3, table.NumColumns())
+	assert.Equal(t, []string{"a", "b", ""}, rowValues(table, 0))
+	assert.Equal(t, "D", table.headers[2].value)
+
+	table.InsertColumn(0, "Z").UpdateCell(0, 0, "z")
+	assert.Equal(t, []string{"z", "a", "b", ""}, rowValues(table, 0))
+	assert.Equal(t, "Z", table.headers[0].value)
+	assert.Equal(t, 1, table.treeColumn)
+	for i, col := range table.columns {
+		assert.Equal(t, i, col.index)
+	}
+
+	table.RemoveColumn(1)
+	assert.Equal(t, []string{"z", "b", ""}, rowValues(table, 0))
+	assert.Equal(t, []string{"Z", "B", "D"}, []string{table.headers[0].value, table.headers[1].value, table.headers[2].value})
+	assert.Equal(t, 0, table.treeColumn)
+}
+
+func TestUpdateRows(t *testing.T) {
+	table := NewTable(2)
+	table.AddRow("a", "b")
+	table.AddRow("c", "d")
+
+	table.UpdateCell(1, 1, "dd")
+	assert.Equal(t, "dd", table.rows[1].cells[1].value)
+	assert.Equal(t, 2, table.rows[1].cells[1].width)
+
+	table.SetRow(0, "x")
+	assert.Equal(t, []string{"x", ""}, rowValues(table, 0))
+
+	table.UpdateCell(5, 0, "ignored")
+	assert.Equal(t, 2, table.NumRows())
+}
+
+func TestDeleteRows(t *testing.T) {
+	table := NewTable(1)
+	a := table.AddTreeRow(TreeRoot, "a")
+	table.AddTreeRow(a, "a1")
+	b := table.AddTreeRow(TreeRoot, "b")
+	table.AddTreeRow(b, "b1")
+
+	table.DeleteRows(a)
+	assert.Equal(t, 2, table.NumRows())
+	assert.Equal(t, "b", table.rows[0].cells[0].value)
+	assert.Equal(t, TreeRoot, table.rows[0].parent)
+	assert.Equal(t, 0, table.rows[1].parent)
+
+	table.ClearRows()
+	assert.Equal(t, 0, table.NumRows())
+}
+
+func TestInsertColumnConcurrentRender(t *testing.T) {
+	table := NewTable(3, SplitColumns(1), MaxWidth(20))
+	table.AddRow("first value", "second value", "third value")
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			table.InsertColumn(0, "")
+			table.AsString()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []int{51}, table.splitKeys)
+}
@@ -0,0 +1,107 @@
+package clt
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ColorScale maps numeric cell values onto a gradient of styles, for example from green
+// to yellow to red.  Use ColumnColorScale to apply a scale to a column.
+type ColorScale struct {
+	styles     []*Style
+	thresholds []float64
+	fixed      bool
+	min        float64
+	max        float64
+}
+
+// NewColorScale returns a scale that spreads the styles evenly between the smallest and largest
+// values in the column.  Use WithRange to use a fixed range instead.
+func NewColorScale(styles ...*Style) ColorScale {
+	return ColorScale{styles: styles}
+}
+
+// ThresholdScale returns a scale that uses styles[i] for values less than thresholds[i] and the
+// last style for values at or above the last threshold.  Thresholds should be in ascending order
+// and there should be one more style than thresholds.
+func ThresholdScale(thresholds []float64, styles ...*Style) ColorScale {
+	return ColorScale{styles: styles, thresholds: thresholds}
+}
+
+// WithRange returns a copy of the scale that spreads its styles between min and max
+// instead of the smallest and largest values in the column
+func (c ColorScale) WithRange(min, max float64) ColorScale {
+	c.fixed = true
+	c.min = min
+	c.max = max
+	return c
+}
+
+// ColumnColorScale colors the cells in column col according to their numeric value when
+// the table is rendered.  Cells that don't start with a number keep their style.  Values may
+// have units after the number, such as 12ms or 50%.
+func (t *Table) ColumnColorScale(col int, scale ColorScale) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if col >= 0 && col < len(t.columns) && len(scale.styles) > 0 {
+		t.columns[col].colorScale = &scale
+	}
+	return t
+}
+
+// style returns the style for value v given the range of values in the column
+func (c *ColorScale) style(v, min, max float64) *Style {
+	if len(c.thresholds) > 0 {
+		for i, threshold := range c.thresholds {
+			if v < threshold && i < len(c.styles) {
+				return c.styles[i]
+			}
+		}
+		return c.styles[len(c.styles)-1]
+	}
+	if c.fixed {
+		min, max = c.min, c.max
+	}
+	if max <= min {
+		return c.styles[0]
+	}
+	frac := math.Min(math.Max((v-min)/(max-min), 0), 1)
+	return c.styles[int(math.Round(frac*float64(len(c.styles)-1)))]
+}
+
+// applyColorScales sets the style of every numeric cell in columns that have a color scale
+func applyColorScales(t *Table) {
+	for col := range t.columns {
+		scale := t.columns[col].colorScale
+		if scale == nil {
+			continue
+		}
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, row := range t.rows {
+			if v, ok := leadingNumber(row.cells[col].value); ok {
+				min = math.Min(min, v)
+				max = math.Max(max, v)
+			}
+		}
+		for _, row := range t.rows {
+			if v, ok := leadingNumber(row.cells[col].value); ok {
+				row.cells[col].style = scale.style(v, min, max)
+			}
+		}
+	}
+}
+
+// leadingNumber parses the number at the start of s, ignoring thousands separators and
+// any units that follow it
+func leadingNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.,+-", r)
+	})
+	if end == -1 {
+		end = len(s)
+	}
+	n, err := strconv.ParseFloat(strings.Replace(s[:end], ",", "", -1), 64)
+	return n, err == nil
+}
@@ -0,0 +1,46 @@
+package clt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeadingNumber(t *testing.T) {
+	n, ok := leadingNumber("12.5ms")
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, n)
+	n, ok = leadingNumber(" 1,240 rows")
+	assert.True(t, ok)
+	assert.Equal(t, 1240.0, n)
+	_, ok = leadingNumber("n/a")
+	assert.False(t, ok)
+}
+
+func TestColorScale(t *testing.T) {
+	green, yellow, red := Styled(Green), Styled(Yellow), Styled(Red)
+
+	t.Run("Gradient over column range", func(t *testing.T) {
+		table := NewTable(1).ColumnColorScale(0, NewColorScale(green, yellow, red))
+		table.AddRow("10ms")
+		table.AddRow("20ms")
+		table.AddRow("30ms")
+		table.AddRow("n/a")
+		v := table.view()
+		assert.Equal(t, green, v.rows[0].cells[0].style)
+		assert.Equal(t, yellow, v.rows[1].cells[0].style)
+		assert.Equal(t, red, v.rows[2].cells[0].style)
+		assert.Equal(t, table.columns[0].style, v.rows[3].cells[0].style)
+	})
+	t.Run("Fixed range", func(t *testing.T) {
+		scale := NewColorScale(green, red).WithRange(0, 100)
+		assert.Equal(t, green, scale.style(20, 0, 30))
+		assert.Equal(t, red, scale.style(80, 0, 30))
+	})
+	t.Run("Thresholds", func(t *testing.T) {
+		scale := ThresholdScale([]float64{1, 5}, green, yellow, red)
+		assert.Equal(t, green, scale.style(0.5, 0, 0))
+		assert.Equal(t, yellow, scale.style(1, 0, 0))
+		assert.Equal(t, red, scale.style(5, 0, 0))
+	})
+}
@@ -0,0 +1,105 @@
+package clt
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Highlight draws every occurrence of substr in the cells of the table with the highlight
+// style.  The rest of each cell keeps its own style.  Calling Highlight again replaces the
+// previous highlight.  Pass an empty string to remove highlighting.
+func (t *Table) Highlight(substr string, sty *Style) *Table {
+	if len(substr) == 0 {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.highlight = nil
+		return t
+	}
+	return t.HighlightRegexp(regexp.MustCompile(regexp.QuoteMeta(substr)), sty)
+}
+
+// HighlightRegexp draws every match of re in the cells of the table with the highlight style.
+// The rest of each cell keeps its own style.  Calling HighlightRegexp again replaces the
+// previous highlight.
+func (t *Table) HighlightRegexp(re *regexp.Regexp, sty *Style) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.highlight = re
+	t.highlightSty = sty
+	return t
+}
+
+// findHighlights records the ranges of visible runes in each cell value that match the
+// highlight.  Escape sequences are left out of the text that is matched.
+func findHighlights(t *Table) {
+	for _, row := range t.rows {
+		for i := range row.cells {
+			c := &row.cells[i]
+			if c.renderer != nil {
+				continue
+			}
+			text := stripEscapes(c.value)
+			var ranges [][]int
+			for _, m := range t.highlight.FindAllStringIndex(text, -1) {
+				if m[1] > m[0] {
+					ranges = append(ranges, []int{utf8.RuneCountInString(text[:m[0]]), utf8.RuneCountInString(text[:m[1]])})
+				}
+			}
+			c.highlights = ranges
+			c.highlightStyle = t.highlightSty
+		}
+	}
+}
+
+// highlightLines wraps the cell value to width w like cellLines and draws the highlighted
+// ranges with the highlight style, restoring base after each one
+func highlightLines(c Cell, col Col, w int, base *Style) []string {
+	if c.highlightStyle == nil {
+		return cellLines(c, col, w)
+	}
+	return wrapHighlighted(c.value, w, col.wrapMode, &highlight{ranges: c.highlights, sty: c.highlightStyle, base: base})
+}
+
+// highlight is a set of ranges of visible runes that are drawn with sty.  Base is the style
+// of the rest of the text.
+type highlight struct {
+	ranges [][]int
+	sty    *Style
+	base   *Style
+}
+
+// covers reports whether rune i is inside one of the ranges
+func (h *highlight) covers(i int) bool {
+	if h == nil {
+		return false
+	}
+	for _, r := range h.ranges {
+		if i >= r[0] && i < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// toggle returns the escape sequences that start the highlight or end it.  Ending it restores
+// the base style and the styles of the text that are active in st.
+func (h *highlight) toggle(on bool, st escapeState) string {
+	if on {
+		return h.sty.before
+	}
+	out := h.sty.after
+	if h.base != nil {
+		out += h.base.before
+	}
+	return out + st.openStyles()
+}
+
+// reapply returns the highlight style again after the text inside a highlight changes its
+// style with seq, so that the highlight stays visible
+func (h *highlight) reapply(lit bool, seq string) string {
+	if !lit || !strings.HasPrefix(seq, "\x1b[") || !strings.HasSuffix(seq, "m") {
+		return ""
+	}
+	return h.sty.before
+}
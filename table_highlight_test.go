@@ -0,0 +1,86 @@
+package clt
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripEscapes(t *testing.T) {
+	assert.Equal(t, "red", stripEscapes(Styled(Red, Bold).ApplyTo("red")))
+	assert.Equal(t, "link", stripEscapes("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"))
+	assert.Equal(t, 4, displayWidth(Styled(Red).ApplyTo("four")))
+}
+
+func TestFindHighlightsStyled(t *testing.T) {
+	table := NewTable(2).Highlight("3", Styled(Yellow))
+	table.AddRow(Styled(Red).ApplyTo("a3"), Link("ticket", "https://example.com/3"))
+	v := table.view()
+	assert.Equal(t, [][]int{{1, 2}}, v.rows[0].cells[0].highlights)
+	assert.Empty(t, v.rows[0].cells[1].highlights)
+}
+
+func TestHighlightWrappedCell(t *testing.T) {
+	hl := Styled(Yellow)
+	table := NewTable(1).HighlightRegexp(regexp.MustCompile("ab cd"), hl)
+	table.AddRow("xx ab cd yy")
+	v := table.view()
+	c := v.rows[0].cells[0]
+	assert.Equal(t, [][]int{{3, 8}}, c.highlights)
+
+	lines := highlightLines(c, v.columns[0], 6, nil)
+	assert.Equal(t, []string{
+		"xx " + hl.ApplyTo("ab"),
+		hl.ApplyTo("cd") + " yy",
+	}, lines)
+
+	table.AddRow("ab\r\nab cd")
+	c = table.view().rows[1].cells[0]
+	assert.Equal(t, []string{"ab", hl.ApplyTo("ab cd")}, highlightLines(c, v.columns[0], 6, nil))
+}
+
+func TestHighlightRender(t *testing.T) {
+	hl := Styled(Yellow)
+	table := NewTable(2).Highlight("err", hl)
+	table.pad = 0
+	table.AddRow("error", "ok")
+	v := table.view()
+	v.computeColWidths()
+	d := Styled(Default)
+	want := d.ApplyTo(hl.ApplyTo("err")+d.before+"or") + d.ApplyTo("ok") + "\n"
+	assert.Equal(t, want, renderRow(v.rows[0].cells, v.columns, v.pad, v.spacing))
+}
+
+func TestHighlightStyledValue(t *testing.T) {
+	red, hl := Styled(Red), Styled(Yellow)
+	table := NewTable(2).Highlight("3", hl)
+	table.pad = 0
+	table.AddRow(red.ApplyTo("a3"), Link("ticket", "https://example.com/3"))
+	v := table.view()
+	v.computeColWidths()
+	got := renderRow(v.rows[0].cells, v.columns, v.pad, v.spacing)
+	assert.Contains(t, got, red.before+"a"+hl.ApplyTo("3"+red.after))
+	assert.Contains(t, got, "\x1b]8;;https://example.com/3\x1b\\ticket\x1b]8;;\x1b\\")
+
+	table.Highlight("ck", hl)
+	v = table.view()
+	v.computeColWidths()
+	got = renderRow(v.rows[0].cells, v.columns, v.pad, v.spacing)
+	assert.Contains(t, got, "\x1b]8;;https://example.com/3\x1b\\ti"+hl.ApplyTo("ck"))
+}
+
+func TestHighlightWrappedStyledValue(t *testing.T) {
+	red, hl := Styled(Red), Styled(Yellow)
+	table := NewTable(1).Highlight("cd ef", hl)
+	table.AddRow(red.ApplyTo("ab cd ef gh"))
+	v := table.view()
+	c := v.rows[0].cells[0]
+
+	lines := highlightLines(c, v.columns[0], 6, nil)
+	assert.Equal(t, []string{
+		red.before + "ab " + hl.ApplyTo("cd") + "\x1b[0m",
+		red.before + hl.ApplyTo("ef") + red.before + " gh" + red.after,
+	}, lines)
+	assert.Equal(t, "ef gh", stripEscapes(lines[1]))
+}
@@ -0,0 +1,162 @@
+package clt
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LiveTable re-renders a table in place, like top.  Each frame moves the cursor back to the
+// top of the previous frame and rewrites only the lines that changed, so the output does not
+// scroll or flicker.  Use Update to change the table while it is live.
+type LiveTable struct {
+	table *Table
+	out   io.Writer
+	prev  []string
+	err   error
+	stop  chan struct{}
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+
+	// stopResize stops re-layout when the terminal is resized
+	stopResize func()
+}
+
+// NewLiveTable returns a live view of the table that writes to the table's writer
+func NewLiveTable(t *Table) *LiveTable {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return &LiveTable{
+		table: t,
+		out:   t.writer,
+	}
+}
+
+// Start draws the first frame and launches a Goroutine to redraw the table every interval.
+// If interval is 0, the table is only redrawn when you call Refresh or Update.  You must
+// always finally call Stop to leave the final frame on screen and restore the cursor.
+func (l *LiveTable) Start(interval time.Duration) {
+	io.WriteString(l.out, hideCursor)
+	l.Refresh()
+	l.stopResize = OnResize(l.resize)
+	if interval <= 0 {
+		return
+	}
+	l.stop = make(chan struct{})
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-tick.C:
+				l.Refresh()
+			}
+		}
+	}()
+}
+
+// Update calls fn to change the table and then redraws it
+func (l *LiveTable) Update(fn func(t *Table)) error {
+	l.mu.Lock()
+	fn(l.table)
+	l.mu.Unlock()
+	return l.Refresh()
+}
+
+// Refresh redraws the table immediately
+func (l *LiveTable) Refresh() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rendered, err := l.table.RenderString()
+	if err != nil {
+		l.err = err
+		return err
+	}
+	l.table.mu.RLock()
+	maxHeight := l.table.maxHeight
+	l.table.mu.RUnlock()
+	lines := frameLines(rendered, maxHeight)
+	if _, err := io.WriteString(l.out, redraw(l.prev, lines)); err != nil {
+		l.err = err
+		return err
+	}
+	l.prev = lines
+	return nil
+}
+
+// Stop ends automatic redraws, draws the final frame and restores the cursor.  It returns
+// the last error from rendering or writing the table.
+func (l *LiveTable) Stop() error {
+	if l.stopResize != nil {
+		l.stopResize()
+		l.stopResize = nil
+	}
+	if l.stop != nil {
+		close(l.stop)
+		l.wg.Wait()
+		l.stop = nil
+	}
+	l.Refresh()
+	io.WriteString(l.out, showCursor)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// resize lays out the table again for the new terminal size and redraws every line, since
+// the terminal may have reflowed the previous frame
+func (l *LiveTable) resize() {
+	l.table.DetectSize()
+	l.mu.Lock()
+	for i := range l.prev {
+		l.prev[i] = "\x00"
+	}
+	l.mu.Unlock()
+	l.Refresh()
+}
+
+// frameLines splits the rendered table into lines, keeping at most maxHeight-1 lines so
+// the frame never scrolls past the top of the terminal, where the cursor can't reach it
+func frameLines(rendered string, maxHeight int) []string {
+	lines := strings.Split(strings.TrimSuffix(rendered, "\n"), "\n")
+	if maxHeight > 1 && len(lines) > maxHeight-1 {
+		lines = lines[:maxHeight-1]
+	}
+	return lines
+}
+
+// redraw returns the output that turns the previous frame into the next one.  The cursor
+// is assumed to be at the start of the line below the previous frame, and is left at the
+// start of the line below the next frame.
+func redraw(prev []string, next []string) string {
+	var out bytes.Buffer
+	// terminals without cursor movement get a full frame every time
+	if len(cursorUp) == 0 {
+		for _, line := range next {
+			out.WriteString(line + "\n")
+		}
+		return out.String()
+	}
+
+	out.WriteString("\r" + strings.Repeat(cursorUp, len(prev)))
+	for i, line := range next {
+		switch {
+		case i < len(prev) && prev[i] == line:
+			out.WriteString("\n")
+		default:
+			out.WriteString(clearLine + line + "\n")
+		}
+	}
+	if len(next) < len(prev) {
+		out.WriteString(clearToEnd)
+	}
+	return out.String()
+}
@@ -0,0 +1,43 @@
+package clt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedraw(t *testing.T) {
+	t.Run("First frame is written in full", func(t *testing.T) {
+		assert.Equal(t, "\r"+clearLine+"a\n"+clearLine+"b\n", redraw(nil, []string{"a", "b"}))
+	})
+	t.Run("Only changed lines are rewritten", func(t *testing.T) {
+		want := "\r" + strings.Repeat(cursorUp, 2) + "\n" + clearLine + "c\n"
+		assert.Equal(t, want, redraw([]string{"a", "b"}, []string{"a", "c"}))
+	})
+	t.Run("Shorter frames clear the leftover lines", func(t *testing.T) {
+		want := "\r" + strings.Repeat(cursorUp, 2) + "\n" + clearToEnd
+		assert.Equal(t, want, redraw([]string{"a", "b"}, []string{"a"}))
+	})
+}
+
+func TestLiveTable(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	table := NewTable(1)
+	table.SetWriter(out)
+	table.AddRow("running")
+
+	live := NewLiveTable(table)
+	live.Start(0)
+	assert.NoError(t, live.Update(func(t *Table) {
+		t.UpdateCell(0, 0, "done")
+	}))
+	assert.NoError(t, live.Stop())
+
+	got := out.String()
+	assert.True(t, strings.HasPrefix(got, hideCursor))
+	assert.True(t, strings.HasSuffix(got, showCursor))
+	assert.Equal(t, 1, strings.Count(got, "done"))
+	assert.Equal(t, 1, strings.Count(got, "running"))
+}
@@ -0,0 +1,77 @@
+package clt
+
+import (
+	"math"
+	"strings"
+)
+
+// unconstrained is the maximum width used to lay out a nested table at its natural width
+const unconstrained = math.MaxInt32
+
+// nestedTable draws another table inside a cell, laid out at the computed column width
+type nestedTable struct {
+	t *Table
+}
+
+// TableCell returns a cell that contains another table.  Use it with AddStyledRow.  The
+// rendered width of the nested table counts toward the natural width of the column, and
+// when the column is narrower the nested table is laid out again to fit, wrapping its own
+// columns as needed.  A nested table without a title is drawn without the blank lines that
+// normally separate the title from the headers.
+func TableCell(t *Table, sty *Style) Cell {
+	return rendererCell(nestedTable{t: t}, sty)
+}
+
+func (n nestedTable) naturalWidth() int {
+	return blockWidth(n.lines(unconstrained))
+}
+
+func (n nestedTable) lines(w int) []string {
+	n.t.mu.RLock()
+	v := n.t.view()
+	n.t.mu.RUnlock()
+	if len(v.title.value) == 0 && len(v.subtitle.value) == 0 {
+		v.titleSpacing = 0
+	}
+	v.maxWidth = w
+	out, err := renderView(v)
+	if err != nil {
+		// the table cannot be narrowed to w, so draw it at its natural width and let
+		// it overflow the column
+		v.maxWidth = unconstrained
+		out, _ = renderView(v)
+	}
+	return strings.Split(strings.TrimRight(out, "\n"), "\n")
+}
+
+// block draws pre-rendered text line by line without wrapping
+type block struct {
+	text []string
+}
+
+// BlockCell returns a cell that contains pre-rendered multi-line text, such as the
+// output of another formatter.  Use it with AddStyledRow.  Lines are never wrapped,
+// so the widest line sets the natural width of the cell.
+func BlockCell(s string, sty *Style) Cell {
+	s = strings.TrimRight(s, "\n")
+	return rendererCell(block{text: strings.Split(s, "\n")}, sty)
+}
+
+func (b block) naturalWidth() int {
+	return blockWidth(b.text)
+}
+
+func (b block) lines(w int) []string {
+	return b.text
+}
+
+// blockWidth returns the display width of the widest line
+func blockWidth(lines []string) int {
+	width := 0
+	for _, line := range lines {
+		if w := displayWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
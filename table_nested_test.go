@@ -0,0 +1,61 @@
+package clt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableCell(t *testing.T) {
+	inner := NewTable(2)
+	inner.ColumnHeaders("host", "cpu")
+	inner.AddRow("web-1", "12%")
+	inner.AddRow("web-2", "80%")
+	innerLines := strings.Split(strings.TrimRight(inner.AsString(), "\n"), "\n")[2:]
+
+	outer := NewTable(2)
+	outer.AddStyledRow(Cell{value: "cluster-a", width: 9}, TableCell(inner, nil))
+	v := outer.view()
+	assert.Equal(t, blockWidth(innerLines), v.rows[0].cells[1].width)
+
+	out := outer.AsString()
+	for _, line := range innerLines {
+		assert.Contains(t, out, line)
+	}
+	assert.Contains(t, out, "cluster-a")
+}
+
+func TestTableCellNarrow(t *testing.T) {
+	inner := NewTable(2)
+	inner.AddRow("a long value that wraps", "b")
+	cell := TableCell(inner, nil)
+	lines := cell.renderer.lines(20)
+	assert.True(t, len(lines) > 1)
+	assert.True(t, blockWidth(lines) <= 20, "%q", lines)
+}
+
+func TestTableCellGrows(t *testing.T) {
+	inner := NewTable(1)
+	inner.AddRow("short")
+	outer := NewTable(2, MaxWidth(80))
+	outer.AddStyledRow(Cell{value: "a", width: 1}, TableCell(inner, nil))
+	inner.AddRow("a much longer value here")
+	outer.AddRow("b", "shorty")
+
+	v := outer.view()
+	assert.Equal(t, 26, v.rows[0].cells[1].width)
+	assert.Contains(t, stripEscapes(outer.AsString()), " b  shorty ")
+}
+
+func TestBlockCell(t *testing.T) {
+	c := BlockCell("one\nthree\n", nil)
+	assert.Equal(t, 5, c.width)
+	assert.Equal(t, []string{"one", "three"}, c.renderer.lines(2))
+
+	table := NewTable(2)
+	table.AddStyledRow(Cell{value: "x", width: 1}, c)
+	out := table.AsString()
+	assert.Contains(t, out, " one   \n")
+	assert.Contains(t, out, " three \n")
+}
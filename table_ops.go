@@ -0,0 +1,250 @@
+package clt
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AggregateFunc reduces the values of a column within a group to a single value
+type AggregateFunc func(values []string) string
+
+// Aggregate describes a column in the result of GroupBy that summarizes column Column of each
+// group using Func.  If Header is empty, the header of the source column is used.
+type Aggregate struct {
+	Column int
+	Func   AggregateFunc
+	Header string
+}
+
+// AggCount returns the number of values in the group
+func AggCount(values []string) string {
+	return strconv.Itoa(len(values))
+}
+
+// AggSum returns the sum of the numeric values in the group.  Values that are not numbers are
+// ignored.  The sum has as many decimal places as the most precise value, and is a percentage
+// if the values are percentages.
+func AggSum(values []string) string {
+	nums, decimals, pct := numbers(values)
+	total := 0.0
+	for _, n := range nums {
+		total += n
+	}
+	return formatNumber(total, decimals, pct)
+}
+
+// AggAvg returns the mean of the numeric values in the group.  Values that are not numbers are
+// ignored, and a group with no numbers has an empty average.
+func AggAvg(values []string) string {
+	nums, decimals, pct := numbers(values)
+	if len(nums) == 0 {
+		return ""
+	}
+	total := 0.0
+	for _, n := range nums {
+		total += n
+	}
+	if decimals < 2 {
+		decimals = 2
+	}
+	return formatNumber(total/float64(len(nums)), decimals, pct)
+}
+
+// AggMin returns the smallest value in the group.  Values are compared by their inferred type, so
+// numbers, percentages, dates and booleans compare by value rather than alphabetically.
+func AggMin(values []string) string {
+	return extreme(values, false)
+}
+
+// AggMax returns the largest value in the group.  See AggMin.
+func AggMax(values []string) string {
+	return extreme(values, true)
+}
+
+// GroupBy returns a new table with one row for each distinct combination of values in cols,
+// followed by a column for each aggregate.  Groups appear in the order they are first seen.
+// The grouping columns keep their headers, styles and justification, and numeric aggregate
+// columns are right-justified.
+func (t *Table) GroupBy(cols []int, aggs ...Aggregate) *Table {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := NewTable(len(cols) + len(aggs))
+	out.title = t.title
+	for i, col := range cols {
+		if col < 0 || col >= len(t.columns) {
+			return NewTable(0)
+		}
+		out.headers[i] = t.headers[col]
+		out.columns[i].justify = t.columns[col].justify
+		out.columns[i].justifySet = t.columns[col].justifySet
+		out.columns[i].style = t.columns[col].style
+	}
+	for i, agg := range aggs {
+		if agg.Column < 0 || agg.Column >= len(t.columns) || agg.Func == nil {
+			return NewTable(0)
+		}
+		header := agg.Header
+		if header == "" {
+			header = t.headers[agg.Column].value
+		}
+		out.headers[len(cols)+i] = Cell{value: header, width: displayWidth(header), style: Styled(Bold, Underline)}
+	}
+
+	var order []string
+	keys := make(map[string][]string)
+	groups := make(map[string][]Row)
+	for _, row := range t.rows {
+		values := make([]string, len(cols))
+		for i, col := range cols {
+			values[i] = row.cells[col].value
+		}
+		k := groupKey(values)
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+			keys[k] = values
+		}
+		groups[k] = append(groups[k], row)
+	}
+
+	for _, k := range order {
+		values := keys[k]
+		for _, agg := range aggs {
+			values = append(values, agg.Func(columnValues(groups[k], agg.Column)))
+		}
+		out.rows = append(out.rows, out.newRow(values))
+	}
+	for i := range aggs {
+		justifyNumeric(out, len(cols)+i)
+	}
+	return out
+}
+
+// Pivot returns a new table that turns a long table into a wide one.  Each distinct value in
+// column rowKey becomes a row and each distinct value in column colKey becomes a column, both in
+// the order they are first seen.  The cells hold the values of column value for each pair of keys
+// reduced by agg, and are empty where no row has that pair of keys.  If agg is nil, the cell
+// holds the value from the last matching row.
+func (t *Table) Pivot(rowKey, colKey, value int, agg AggregateFunc) *Table {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, col := range []int{rowKey, colKey, value} {
+		if col < 0 || col >= len(t.columns) {
+			return NewTable(0)
+		}
+	}
+	if agg == nil {
+		agg = last
+	}
+
+	var rowOrder, colOrder []string
+	rowIndex := make(map[string]int)
+	colIndex := make(map[string]int)
+	var cells [][][]string
+	for _, row := range t.rows {
+		r, c := row.cells[rowKey].value, row.cells[colKey].value
+		if _, ok := rowIndex[r]; !ok {
+			rowIndex[r] = len(rowOrder)
+			rowOrder = append(rowOrder, r)
+			cells = append(cells, nil)
+		}
+		if _, ok := colIndex[c]; !ok {
+			colIndex[c] = len(colOrder)
+			colOrder = append(colOrder, c)
+		}
+		ri, ci := rowIndex[r], colIndex[c]
+		for len(cells[ri]) <= ci {
+			cells[ri] = append(cells[ri], nil)
+		}
+		cells[ri][ci] = append(cells[ri][ci], row.cells[value].value)
+	}
+
+	out := NewTable(len(colOrder) + 1)
+	out.title = t.title
+	out.headers[0] = t.headers[rowKey]
+	out.columns[0].justify = t.columns[rowKey].justify
+	out.columns[0].justifySet = t.columns[rowKey].justifySet
+	out.columns[0].style = t.columns[rowKey].style
+	for i, c := range colOrder {
+		out.headers[i+1] = Cell{value: c, width: displayWidth(c), style: Styled(Bold, Underline)}
+	}
+	for ri, r := range rowOrder {
+		values := make([]string, len(colOrder)+1)
+		values[0] = r
+		for ci, group := range cells[ri] {
+			if len(group) > 0 {
+				values[ci+1] = agg(group)
+			}
+		}
+		out.rows = append(out.rows, out.newRow(values))
+	}
+	for i := range colOrder {
+		justifyNumeric(out, i+1)
+	}
+	return out
+}
+
+// groupKey joins values into a single map key
+func groupKey(values []string) string {
+	return strings.Join(values, "\x00")
+}
+
+func last(values []string) string {
+	return values[len(values)-1]
+}
+
+// justifyNumeric right-justifies column col if all of its values are numbers
+func justifyNumeric(t *Table, col int) {
+	switch inferType(columnValues(t.rows, col)) {
+	case Integer, Float, Percent:
+		t.columns[col].justify = Right
+	}
+}
+
+// numbers parses the numeric values, returning the largest number of decimal places seen and
+// whether every number was a percentage
+func numbers(values []string) ([]float64, int, bool) {
+	var nums []float64
+	decimals := 0
+	pct := true
+	for _, v := range values {
+		n, ok := parseNumber(v)
+		if !ok {
+			continue
+		}
+		nums = append(nums, n)
+		v = strings.TrimSpace(v)
+		if !strings.HasSuffix(v, "%") {
+			pct = false
+		}
+		if i := strings.IndexByte(v, '.'); i >= 0 {
+			if d := len(strings.TrimSuffix(v[i+1:], "%")); d > decimals {
+				decimals = d
+			}
+		}
+	}
+	return nums, decimals, pct && len(nums) > 0
+}
+
+func formatNumber(n float64, decimals int, pct bool) string {
+	s := strconv.FormatFloat(n, 'f', decimals, 64)
+	if pct {
+		s += "%"
+	}
+	return s
+}
+
+// extreme returns the smallest or largest non-empty value compared by inferred type
+func extreme(values []string, largest bool) string {
+	typ := inferType(values)
+	out := ""
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		if out == "" || (largest && lessValue(typ, out, v)) || (!largest && lessValue(typ, v, out)) {
+			out = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package clt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func opsTable() *Table {
+	table := NewTable(4)
+	table.ColumnHeaders("Cluster", "Host", "Region", "CPU")
+	table.AddRow("a", "web-1", "us", "10")
+	table.AddRow("b", "db-1", "eu", "2.5")
+	table.AddRow("a", "web-2", "eu", "30")
+	table.AddRow("a", "web-3", "us", "20")
+	return table
+}
+
+func TestAggregates(t *testing.T) {
+	assert.Equal(t, "3", AggCount([]string{"a", "b", "c"}))
+	assert.Equal(t, "0.3", AggSum([]string{"0.1", "0.2", "n/a"}))
+	assert.Equal(t, "30%", AggSum([]string{"10%", "20%"}))
+	assert.Equal(t, "2.50", AggAvg([]string{"2", "3"}))
+	assert.Equal(t, "", AggAvg([]string{"x"}))
+	assert.Equal(t, "9", AggMin([]string{"10", "9", ""}))
+	assert.Equal(t, "10", AggMax([]string{"10", "9"}))
+	assert.Equal(t, "2020-03-01", AggMax([]string{"2019-12-31", "2020-03-01"}))
+}
+
+func TestGroupBy(t *testing.T) {
+	out := opsTable().GroupBy([]int{0},
+		Aggregate{Column: 1, Func: AggCount, Header: "Hosts"},
+		Aggregate{Column: 3, Func: AggSum},
+	)
+	assert.Equal(t, 3, out.NumColumns())
+	assert.Equal(t, []string{"Cluster", "Hosts", "CPU"}, []string{out.headers[0].value, out.headers[1].value, out.headers[2].value})
+	assert.Equal(t, [][]string{{"a", "3", "60"}, {"b", "1", "2.5"}}, allRows(out))
+	assert.Equal(t, Right, out.columns[2].justify)
+	assert.Equal(t, Left, out.columns[0].justify)
+
+	multi := opsTable().GroupBy([]int{0, 2}, Aggregate{Column: 3, Func: AggMax})
+	assert.Equal(t, [][]string{{"a", "us", "20"}, {"b", "eu", "2.5"}, {"a", "eu", "30"}}, allRows(multi))
+}
+
+func TestPivot(t *testing.T) {
+	out := opsTable().Pivot(0, 2, 3, AggSum)
+	assert.Equal(t, []string{"Cluster", "us", "eu"}, []string{out.headers[0].value, out.headers[1].value, out.headers[2].value})
+	assert.Equal(t, [][]string{{"a", "30", "30"}, {"b", "", "2.5"}}, allRows(out))
+
+	last := opsTable().Pivot(2, 0, 1, nil)
+	assert.Equal(t, [][]string{{"us", "web-3", ""}, {"eu", "web-2", "db-1"}}, allRows(last))
+}
+
+func allRows(t *Table) [][]string {
+	var out [][]string
+	for i := range t.rows {
+		out = append(out, rowValues(t, i))
+	}
+	return out
+}
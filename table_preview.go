@@ -0,0 +1,104 @@
+package clt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RowNumbers adds a column before the first column that numbers each row in the order it
+// is rendered
+func RowNumbers() TableOption {
+	return func(t *Table) error {
+		t.rowNumbers = true
+		return nil
+	}
+}
+
+// Head limits rendering to the first n rows followed by a summary of how many rows were left
+// out.  It can be combined with Tail to show both ends of the table.
+func Head(n int) TableOption {
+	return func(t *Table) error {
+		t.head = n
+		return nil
+	}
+}
+
+// Tail limits rendering to the last n rows preceded by a summary of how many rows were left
+// out.  It can be combined with Head to show both ends of the table.
+func Tail(n int) TableOption {
+	return func(t *Table) error {
+		t.tail = n
+		return nil
+	}
+}
+
+// addRowNumbers inserts a right-justified column numbering the rows in display order
+func addRowNumbers(t *Table) {
+	num := Col{style: Styled(Dim), justify: Right, justifySet: true}
+	t.columns = append([]Col{num}, t.columns...)
+	for i := range t.columns {
+		t.columns[i].index = i
+	}
+	t.headers = insertCell(t.headers, 0, Cell{value: "#", width: 1, style: Styled(Bold, Underline)})
+	for r := range t.rows {
+		n := strconv.Itoa(r + 1)
+		t.rows[r].cells = insertCell(t.rows[r].cells, 0, Cell{value: n, width: len(n), style: num.style})
+	}
+
+	t.treeColumn++
+	// the numbers repeat in every panel of a split table, but don't split a table by themselves
+	if len(t.splitKeys) == 0 {
+		return
+	}
+	keys := []int{0}
+	for _, key := range t.splitKeys {
+		keys = append(keys, key+1)
+	}
+	t.splitKeys = keys
+}
+
+// truncateRows keeps the first head and last tail rows, replacing the rest with a summary row
+func truncateRows(t *Table) {
+	if (t.head <= 0 && t.tail <= 0) || t.head+t.tail >= len(t.rows) {
+		return
+	}
+	head, tail := t.head, t.tail
+	if head < 0 {
+		head = 0
+	}
+	if tail < 0 {
+		tail = 0
+	}
+	hidden := len(t.rows) - head - tail
+	summary := Row{parent: TreeRoot, summary: fmt.Sprintf("... %s more rows", formatCount(hidden))}
+	if hidden == 1 {
+		summary.summary = "... 1 more row"
+	}
+
+	rows := append([]Row{}, t.rows[:head]...)
+	rows = append(rows, summary)
+	t.rows = append(rows, t.rows[len(t.rows)-tail:]...)
+}
+
+// renderSummary renders a line that spans the table in a dim style
+func renderSummary(s string, pad int, spacing int) string {
+	out := spaces(pad) + Styled(Dim).ApplyTo(s) + "\n"
+	if spacing > 1 {
+		out += strings.Repeat("\n", spacing-1)
+	}
+	return out
+}
+
+// formatCount formats n with thousands separators
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	var out strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out.WriteRune(',')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
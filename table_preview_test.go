@@ -0,0 +1,72 @@
+package clt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatCount(t *testing.T) {
+	assert.Equal(t, "7", formatCount(7))
+	assert.Equal(t, "999", formatCount(999))
+	assert.Equal(t, "1,240", formatCount(1240))
+	assert.Equal(t, "1,000,000", formatCount(1000000))
+}
+
+func TestRowNumbers(t *testing.T) {
+	table := NewTable(1, RowNumbers())
+	table.AddRow("a")
+	table.AddRow("b")
+	v := table.view()
+	assert.Len(t, v.columns, 2)
+	assert.Equal(t, "#", v.headers[0].value)
+	assert.Equal(t, []string{"2", "b"}, []string{v.rows[1].cells[0].value, v.rows[1].cells[1].value})
+	assert.Equal(t, Right, v.columns[0].justify)
+	assert.Len(t, table.columns, 1)
+}
+
+func TestRowNumbersSplit(t *testing.T) {
+	table := NewTable(3, RowNumbers(), MaxWidth(40))
+	table.AddRow(s(15), s(15), s(30))
+	assert.Empty(t, table.view().splitKeys)
+	out, err := table.RenderString()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(out, "\n\n"))
+
+	table = NewTable(3, RowNumbers(), SplitColumns(1))
+	assert.Equal(t, []int{0, 2}, table.view().splitKeys)
+}
+
+func TestHeadTail(t *testing.T) {
+	rows := func(opts ...TableOption) []string {
+		table := NewTable(1, opts...)
+		for i := 1; i <= 1245; i++ {
+			table.AddRow(fmt.Sprintf("r%d", i))
+		}
+		var out []string
+		for _, row := range table.view().rows {
+			switch {
+			case len(row.summary) > 0:
+				out = append(out, row.summary)
+			default:
+				out = append(out, row.cells[0].value)
+			}
+		}
+		return out
+	}
+	assert.Equal(t, []string{"r1", "r2", "... 1,243 more rows"}, rows(Head(2)))
+	assert.Equal(t, []string{"... 1,243 more rows", "r1244", "r1245"}, rows(Tail(2)))
+	assert.Equal(t, []string{"r1", "... 1,242 more rows", "r1244", "r1245"}, rows(Head(1), Tail(2)))
+	assert.Len(t, rows(Head(2000)), 1245)
+}
+
+func TestRenderSummary(t *testing.T) {
+	table := NewTable(3, Head(1), SplitColumns(0))
+	table.maxWidth = 25
+	table.AddRow(s(8), s(8), s(8))
+	table.AddRow(s(8), s(8), s(8))
+	out := table.AsString()
+	assert.Equal(t, 2, strings.Count(out, Styled(Dim).ApplyTo("... 1 more row")))
+}
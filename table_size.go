@@ -0,0 +1,74 @@
+package clt
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Size used when the terminal size cannot be detected
+const (
+	defaultWidth  = 80
+	defaultHeight = 25
+)
+
+// detectSize returns the size of the terminal that w writes to.  If w is not a terminal, the
+// size is taken from the COLUMNS and LINES environment variables and then the defaults.
+func detectSize(w io.Writer) (width, height int) {
+	if f, ok := w.(*os.File); ok {
+		if width, height, err := terminalSize(f.Fd()); err == nil && width > 0 && height > 0 {
+			return width, height
+		}
+	}
+	return envSize("COLUMNS", defaultWidth), envSize("LINES", defaultHeight)
+}
+
+func envSize(name string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// OnResize calls fn each time the terminal is resized until stop is called.  Use it to re-layout
+// long-lived output with DetectSize.  On Windows fn is never called.
+func OnResize(fn func()) (stop func()) {
+	var once sync.Once
+	stopNotify := notifyResize(fn)
+	return func() {
+		once.Do(stopNotify)
+	}
+}
+
+// DetectSize detects the size of the terminal the table writes to again, for example after the
+// terminal is resized.  Limits set with MaxWidth and MaxHeight still apply.
+func (t *Table) DetectSize() *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.setSize(detectSize(t.writer))
+	return t
+}
+
+// SetSize sets the terminal size used to lay out and page the table instead of detecting it,
+// which is useful for rendering the same output on every machine.  Limits set with MaxWidth and
+// MaxHeight still apply.  The size is detected again by SetWriter and DetectSize.
+func (t *Table) SetSize(width, height int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.setSize(width, height)
+	return t
+}
+
+// setSize sets the maximum width and height of the table to the terminal size, unless they
+// were limited by MaxWidth or MaxHeight
+func (t *Table) setSize(width, height int) {
+	t.maxWidth = width
+	if t.widthLimit > 0 && t.widthLimit < width {
+		t.maxWidth = t.widthLimit
+	}
+	t.maxHeight = height
+	if t.heightLimit > 0 && t.heightLimit < height {
+		t.maxHeight = t.heightLimit
+	}
+}
@@ -4,16 +4,13 @@ package clt
 
 import (
 	"os"
+	"os/signal"
 	"syscall"
 	"unsafe"
 )
 
-// Magic from the go source for ssh/terminal to find terminal size.  Because it is
-// difficult to test this implementation across os/terminal combinations, you can skip
-// this check by setting Table.SkipTermSize=true and set the Table.maxWidth and
-// Table.MaxHeight manually.  Or, Table will set appropriate conservative defaults.
-func getTerminalSize() (width, height int, err error) {
-	fd := os.Stdout.Fd()
+// terminalSize returns the size of the terminal open on file descriptor fd
+func terminalSize(fd uintptr) (width, height int, err error) {
 	var dimensions [4]uint16
 
 	if _, _, err := syscall.Syscall6(syscall.SYS_IOCTL, uintptr(fd), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&dimensions)), 0, 0, 0); err != 0 {
@@ -21,3 +18,24 @@ func getTerminalSize() (width, height int, err error) {
 	}
 	return int(dimensions[1]), int(dimensions[0]), nil
 }
+
+// notifyResize calls fn each time the terminal is resized until stop is called
+func notifyResize(fn func()) (stop func()) {
+	sig := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sig, syscall.SIGWINCH)
+	go func() {
+		for {
+			select {
+			case <-sig:
+				fn()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
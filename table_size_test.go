@@ -0,0 +1,58 @@
+package clt
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSizeEnv(t *testing.T) {
+	defer os.Setenv("COLUMNS", os.Getenv("COLUMNS"))
+	defer os.Setenv("LINES", os.Getenv("LINES"))
+
+	os.Setenv("COLUMNS", "132")
+	os.Setenv("LINES", "50")
+	w, h := detectSize(&bytes.Buffer{})
+	assert.Equal(t, 132, w)
+	assert.Equal(t, 50, h)
+
+	os.Setenv("COLUMNS", "wide")
+	os.Unsetenv("LINES")
+	w, h = detectSize(&bytes.Buffer{})
+	assert.Equal(t, defaultWidth, w)
+	assert.Equal(t, defaultHeight, h)
+}
+
+func TestSetWriterSize(t *testing.T) {
+	defer os.Setenv("COLUMNS", os.Getenv("COLUMNS"))
+	defer os.Setenv("LINES", os.Getenv("LINES"))
+	os.Setenv("COLUMNS", "200")
+	os.Setenv("LINES", "60")
+
+	table := NewTable(1, MaxWidth(100))
+	table.SetWriter(&bytes.Buffer{})
+	assert.Equal(t, 100, table.maxWidth)
+	assert.Equal(t, 60, table.maxHeight)
+
+	os.Setenv("COLUMNS", "90")
+	table.DetectSize()
+	assert.Equal(t, 90, table.maxWidth)
+
+	table = NewTable(1, MaxHeight(10))
+	table.SetWriter(&bytes.Buffer{})
+	assert.Equal(t, 90, table.maxWidth)
+	assert.Equal(t, 10, table.maxHeight)
+
+	table.SetSize(90, 5)
+	assert.Equal(t, 5, table.maxHeight)
+	table.SetSize(90, 40)
+	assert.Equal(t, 10, table.maxHeight)
+}
+
+func TestOnResizeStop(t *testing.T) {
+	stop := OnResize(func() {})
+	stop()
+	stop()
+}
@@ -7,3 +7,12 @@ import "fmt"
 func getTerminalSize() (width, height int, err error) {
 	return -1, -1, fmt.Errorf("fuck windows")
 }
+
+func terminalSize(fd uintptr) (width, height int, err error) {
+	return getTerminalSize()
+}
+
+// notifyResize does nothing because Windows consoles do not signal when they are resized
+func notifyResize(fn func()) (stop func()) {
+	return func() {}
+}
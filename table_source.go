@@ -0,0 +1,167 @@
+package clt
+
+import (
+	"bytes"
+	"io"
+)
+
+// sourceBatch is the number of rows fetched from a RowSource at a time while rendering
+const sourceBatch = 256
+
+// defaultSampleSize is the number of rows used to compute column widths for a RowSource
+// when no sample size is given
+const defaultSampleSize = 100
+
+// RowSource provides rows to a table on demand so that very large or virtual tables never
+// need to be loaded into memory.
+type RowSource interface {
+	// Len returns the total number of rows
+	Len() int
+	// Rows returns the rows from start up to but not including end, with one Cell per column.
+	// Create cells with StyledCell.  Cells without a style use the column style.
+	Rows(start, end int) ([][]Cell, error)
+}
+
+// SetRowSource renders the table from src instead of from rows added to the table.  Column widths
+// are computed from the first sampleSize rows (100 if sampleSize is 0) unless they are declared
+// with ColumnWidths.  Rows from the source are rendered in order with the title, headers, styles,
+// wrapping and highlighting of the table.  Trees, sorting, previews and split panels only apply
+// to rows added to the table directly.
+func (t *Table) SetRowSource(src RowSource, sampleSize int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if sampleSize <= 0 {
+		sampleSize = defaultSampleSize
+	}
+	t.source = src
+	t.sampleSize = sampleSize
+	return t
+}
+
+// ColumnWidths declares the natural width of each column instead of measuring the values in
+// the column.  A width of 0 keeps the measured width.  Longer values are wrapped as usual.
+func (t *Table) ColumnWidths(widths ...int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, w := range widths {
+		if i >= len(t.columns) {
+			return t
+		}
+		t.columns[i].fixedWidth = w
+	}
+	return t
+}
+
+// renderSource streams the rows of the row source to w in batches
+func (t *Table) renderSource(w io.Writer) error {
+	t.mu.RLock()
+	v, err := t.sourceLayout()
+	t.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, renderTop(v)+renderHeaders(v.headers, v.columns, v.pad)); err != nil {
+		return err
+	}
+	total := v.source.Len()
+	for start := 0; start < total; start += sourceBatch {
+		if err := v.fetch(start, start+sourceBatch); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, renderRows(v)); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, renderBottom(v))
+	return err
+}
+
+// showSourcePage renders n rows of the row source at a time, pausing after each page
+func (t *Table) showSourcePage(n int) {
+	if n < 1 {
+		n = 1
+	}
+	t.mu.RLock()
+	v, err := t.sourceLayout()
+	t.mu.RUnlock()
+	if err != nil {
+		return
+	}
+	io.WriteString(v.writer, renderTop(v)+renderHeaders(v.headers, v.columns, v.pad))
+	sess := NewInteractiveSession()
+
+	total := v.source.Len()
+	for start := 0; start < total; start += n {
+		if err := v.fetch(start, start+n); err != nil {
+			return
+		}
+		io.WriteString(v.writer, renderRows(v))
+		if end := start + len(v.rows); end < total {
+			sess.PauseWithPrompt("\nResults %d-%d of %d. Press [Enter] to continue.\n", start+1, end, total)
+		}
+	}
+	io.WriteString(v.writer, renderBottom(v))
+}
+
+// sourceLayout returns a view of the table with column widths computed from a sample of
+// the row source and any declared widths
+func (t *Table) sourceLayout() (*Table, error) {
+	v := t.derive()
+	v.columns = make([]Col, len(t.columns))
+	v.headers = make([]Cell, len(t.headers))
+	copy(v.columns, t.columns)
+	copy(v.headers, t.headers)
+
+	declared := true
+	for _, col := range v.columns {
+		declared = declared && col.fixedWidth > 0
+	}
+	if !declared {
+		if err := v.fetch(0, v.sampleSize); err != nil {
+			return nil, err
+		}
+	}
+	normalizeCells(v)
+	if err := v.computeColWidths(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// fetch replaces the rows of the view with rows start to end of the row source
+func (t *Table) fetch(start, end int) error {
+	if total := t.source.Len(); end > total {
+		end = total
+	}
+	t.rows = nil
+	if start >= end {
+		return nil
+	}
+	cells, err := t.source.Rows(start, end)
+	if err != nil {
+		return err
+	}
+	for _, rowCells := range cells {
+		row := t.newStyledRow(rowCells)
+		for i := range row.cells {
+			if row.cells[i].style == nil {
+				row.cells[i].style = t.columns[i].style
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	normalizeCells(t)
+	if t.highlight != nil {
+		findHighlights(t)
+	}
+	return nil
+}
+
+// renderRows renders the rows of a table that has already been laid out
+func renderRows(t *Table) string {
+	var out bytes.Buffer
+	for _, row := range t.rows {
+		out.WriteString(renderRow(row.cells, t.columns, t.pad, t.spacing))
+	}
+	return out.String()
+}
@@ -0,0 +1,66 @@
+package clt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSource struct {
+	n       int
+	fetched int
+}
+
+func (s *testSource) Len() int { return s.n }
+
+func (s *testSource) Rows(start, end int) ([][]Cell, error) {
+	var rows [][]Cell
+	for i := start; i < end; i++ {
+		rows = append(rows, []Cell{StyledCell(fmt.Sprintf("row%d", i), nil), StyledCell(strings.Repeat("x", i%7), nil)})
+	}
+	s.fetched += end - start
+	return rows, nil
+}
+
+type errSource struct{}
+
+func (errSource) Len() int { return 10 }
+
+func (errSource) Rows(start, end int) ([][]Cell, error) {
+	return nil, fmt.Errorf("query failed")
+}
+
+func TestRowSource(t *testing.T) {
+	src := &testSource{n: 1000}
+	table := NewTable(2).SetRowSource(src, 3)
+	table.pad = 0
+
+	var out bytes.Buffer
+	assert.NoError(t, table.Render(&out))
+	assert.Equal(t, 1003, src.fetched)
+	assert.Contains(t, out.String(), Styled(Default).ApplyTo("row0"))
+	assert.True(t, strings.Count(out.String(), "\n") >= 1002)
+
+	t.Run("Widths come from the sample", func(t *testing.T) {
+		v, err := table.sourceLayout()
+		assert.NoError(t, err)
+		assert.Equal(t, []int{4, 2}, extractNatWidth(v))
+	})
+	t.Run("Declared widths skip the sample", func(t *testing.T) {
+		src.fetched = 0
+		table.ColumnWidths(6, 6)
+		v, err := table.sourceLayout()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, src.fetched)
+		assert.Equal(t, []int{6, 6}, extractNatWidth(v))
+	})
+}
+
+func TestRowSourceError(t *testing.T) {
+	table := NewTable(2).SetRowSource(errSource{}, 0)
+	_, err := table.RenderString()
+	assert.EqualError(t, err, "query failed")
+}
@@ -0,0 +1,123 @@
+package clt
+
+import (
+	"bytes"
+	"sort"
+)
+
+// SplitColumns renders tables that are too wide for the terminal as several stacked panels
+// instead of wrapping or overflowing.  Each panel shows as many columns as will fit and repeats
+// the key columns so that rows can be matched across panels.  Column indexes outside the table
+// are ignored.
+func SplitColumns(keys ...int) TableOption {
+	return func(t *Table) error {
+		t.splitKeys = nil
+		for _, key := range keys {
+			if key >= 0 && key < len(t.columns) && !containsInt(t.splitKeys, key) {
+				t.splitKeys = append(t.splitKeys, key)
+			}
+		}
+		sort.Ints(t.splitKeys)
+		return nil
+	}
+}
+
+// splitPanels partitions the columns of the view into panels that each fit within the
+// max width.  It returns a single panel when the table fits or when the key columns
+// alone are too wide to leave room for any other column.
+func splitPanels(v *Table) []*Table {
+	computeNaturalWidths(v)
+	padded := mapAdd(extractNatWidth(v), 2*v.pad)
+	if sum(padded) <= v.maxWidth {
+		return []*Table{v}
+	}
+
+	keyW := 0
+	for _, key := range v.splitKeys {
+		keyW += padded[key]
+	}
+	avail := v.maxWidth - keyW
+	if avail <= 0 {
+		return []*Table{v}
+	}
+
+	var groups [][]int
+	var group []int
+	groupW := 0
+	for i := range v.columns {
+		if containsInt(v.splitKeys, i) {
+			continue
+		}
+		if len(group) > 0 && groupW+padded[i] > avail {
+			groups = append(groups, group)
+			group, groupW = nil, 0
+		}
+		group = append(group, i)
+		groupW += padded[i]
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	panels := make([]*Table, len(groups))
+	for n, group := range groups {
+		cols := append(append([]int{}, v.splitKeys...), group...)
+		sort.Ints(cols)
+		panels[n] = v.project(cols)
+	}
+	return panels
+}
+
+// renderPanels lays out and renders each panel with the title above the first panel
+// and a blank line between panels
+func renderPanels(panels []*Table) (string, error) {
+	var out bytes.Buffer
+	for n, p := range panels {
+		if err := p.computeColWidths(); err != nil {
+			return "", err
+		}
+		switch n {
+		case 0:
+			out.WriteString(renderTop(p))
+		default:
+			out.WriteString("\n")
+		}
+		out.WriteString(renderBody(p))
+	}
+	out.WriteString(renderBottom(panels[len(panels)-1]))
+	return out.String(), nil
+}
+
+// project returns a copy of the table with only the columns in cols
+func (t *Table) project(cols []int) *Table {
+	p := t.derive()
+	p.splitKeys = nil
+	for n, i := range cols {
+		p.columns = append(p.columns, t.columns[i])
+		p.headers = append(p.headers, t.headers[i])
+		if i == t.treeColumn {
+			p.treeColumn = n
+		}
+	}
+	p.rows = make([]Row, len(t.rows))
+	for r, row := range t.rows {
+		p.rows[r] = row
+		if len(row.summary) > 0 {
+			continue
+		}
+		p.rows[r].cells = make([]Cell, len(cols))
+		for n, i := range cols {
+			p.rows[r].cells[n] = row.cells[i]
+		}
+	}
+	return p
+}
+
+func containsInt(n []int, want int) bool {
+	for _, num := range n {
+		if num == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package clt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPanels(t *testing.T) {
+	table := NewTable(4, SplitColumns(0))
+	table.pad = 0
+	table.maxWidth = 30
+	table.ColumnHeaders("Name", "A", "B", "C")
+	key := strings.Repeat("k", 10)
+	table.AddRow(key, s(12), s(12), s(6))
+
+	panels := splitPanels(table.view())
+	assert.Len(t, panels, 2)
+	assert.Equal(t, "Name", panels[0].headers[0].value)
+	assert.Equal(t, "A", panels[0].headers[1].value)
+	assert.Equal(t, "Name", panels[1].headers[0].value)
+	assert.Equal(t, []string{"Name", "B", "C"}, []string{panels[1].headers[0].value, panels[1].headers[1].value, panels[1].headers[2].value})
+
+	t.Run("Each panel repeats the key column", func(t *testing.T) {
+		out := table.AsString()
+		assert.Equal(t, 2, strings.Count(out, key))
+	})
+}
+
+func TestSplitPanelsFits(t *testing.T) {
+	table := NewTable(3, SplitColumns(0))
+	table.maxWidth = 80
+	table.AddRow(s(10), s(10), s(10))
+	assert.Len(t, splitPanels(table.view()), 1)
+}
+
+func TestSplitPanelsKeysTooWide(t *testing.T) {
+	table := NewTable(2, SplitColumns(0))
+	table.pad = 0
+	table.maxWidth = 10
+	table.AddRow(s(20), s(5))
+	assert.Len(t, splitPanels(table.view()), 1)
+}
@@ -2,8 +2,10 @@ package clt
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/BTBurke/snapshot"
@@ -12,7 +14,7 @@ import (
 
 func TestTerminalSizeCheck(t *testing.T) {
 	t.Skipf("Terminal check skipped. No TTY.")
-	h, w, err := getTerminalSize()
+	h, w, err := terminalSize(os.Stdout.Fd())
 	if err != nil || h == -1 || w == -1 {
 		fmt.Printf("Cannot determine terminal size for Table. This will still work, but will not be able to automagically determine sizes.")
 	}
@@ -315,3 +317,90 @@ func TestHeadersShort(t *testing.T) {
 		snapshot.Assert(t, []byte(table.AsString()))
 	})
 }
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("write failed")
+}
+
+func TestRender(t *testing.T) {
+	table := NewTable(2)
+	table.AddRow(s(10), s(10))
+	table.maxWidth = 28
+	t.Run("Render writes same output as AsString", func(t *testing.T) {
+		var out strings.Builder
+		assert.NoError(t, table.Render(&out))
+		assert.Equal(t, table.AsString(), out.String())
+	})
+	t.Run("Render returns writer errors", func(t *testing.T) {
+		assert.EqualError(t, table.Render(errWriter{}), "write failed")
+	})
+	t.Run("RenderString returns rendered table", func(t *testing.T) {
+		got, err := table.RenderString()
+		assert.NoError(t, err)
+		assert.Equal(t, table.AsString(), got)
+	})
+}
+
+func TestWrapNewlines(t *testing.T) {
+	assert.Equal(t, []string{"first", "line", "", "second"}, wrap("first line\n\nsecond\n", 6))
+	assert.Equal(t, []string{"a", "b"}, wrap("a\r\nb", 10))
+}
+
+func TestExpandTabs(t *testing.T) {
+	assert.Equal(t, "a   b", expandTabs("a\tb", 4))
+	assert.Equal(t, "abcd    e", expandTabs("abcd\te", 4))
+	assert.Equal(t, "a\n    b", expandTabs("a\n\tb", 4))
+}
+
+func TestMultilineCell(t *testing.T) {
+	table := NewTable(2, TabWidth(4))
+	table.pad = 0
+	table.AddRow("error:\tfailed\nretrying", "x")
+	v := table.view()
+	v.computeColWidths()
+	assert.Equal(t, []int{14, 1}, extractNatWidth(v))
+	d := Styled(Default)
+	want := d.ApplyTo("error:  failed") + d.ApplyTo("x") + "\n" + d.ApplyTo("retrying") + "      " + d.ApplyTo("") + " \n"
+	assert.Equal(t, want, renderRow(v.rows[0].cells, v.columns, v.pad, v.spacing))
+}
+
+func TestConcurrentAddRow(t *testing.T) {
+	table := NewTable(2)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("%03d", i)
+			switch i % 2 {
+			case 0:
+				table.AddRowWithKey(key, key, "even")
+			default:
+				table.AddStyledRowWithKey(key, StyledCell(key, nil), StyledCell("odd", nil))
+			}
+			table.AsString()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, table.NumRows())
+	rows := table.view().rows
+	for i, row := range rows {
+		assert.Equal(t, fmt.Sprintf("%03d", i), row.cells[0].value)
+	}
+}
+
+func TestKeyOrderWithSort(t *testing.T) {
+	table := NewTable(2)
+	table.AddRowWithKey("b", "b", "1")
+	table.AddRowWithKey("a", "a", "2")
+	table.AddRowWithKey("c", "c", "1")
+	table.SortBy(1, false)
+	var got []string
+	for _, row := range table.view().rows {
+		got = append(got, row.cells[0].value)
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, got)
+}
@@ -0,0 +1,152 @@
+package clt
+
+import "fmt"
+
+// TreeRoot is the parent index used with AddTreeRow to add a top-level row to a tree table
+const TreeRoot = -1
+
+// Tree guides drawn in the tree column.  Each guide is the same width so that
+// indentation lines up across depths.
+const (
+	treeBranch = "├─ "
+	treeLast   = "└─ "
+	treePipe   = "│  "
+	treeBlank  = "   "
+)
+
+// AddTreeRow adds a row as a child of the row at index parent and returns the index of the new
+// row.  Use TreeRoot as the parent for top-level rows.  Rows are rendered depth first under their
+// parent with tree guides drawn in the tree column (see TreeColumn).
+func (t *Table) AddTreeRow(parent int, rowStrings ...string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows = append(t.rows, t.newRow(rowStrings))
+	return t.setParent(parent)
+}
+
+// AddStyledTreeRow is like AddTreeRow but with custom styles for each Cell
+func (t *Table) AddStyledTreeRow(parent int, cells ...Cell) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rows = append(t.rows, t.newStyledRow(cells))
+	return t.setParent(parent)
+}
+
+// setParent sets the parent of the last row added and returns its index
+func (t *Table) setParent(parent int) int {
+	i := len(t.rows) - 1
+	if parent >= 0 && parent < i {
+		t.rows[i].parent = parent
+	}
+	return i
+}
+
+// TreeColumn sets the column in which tree guides are drawn.  The default is the first column.
+func (t *Table) TreeColumn(i int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i >= 0 && i < len(t.columns) {
+		t.treeColumn = i
+	}
+	return t
+}
+
+// CollapseTree hides all rows nested deeper than depth, where top-level rows have a depth of 0.
+// Rows with hidden descendants show the number of hidden rows.  Pass a negative depth to
+// show the whole tree.
+func (t *Table) CollapseTree(depth int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.collapseDepth = depth
+	return t
+}
+
+// Collapse hides all descendants of the row at index i
+func (t *Table) Collapse(i int) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i >= 0 && i < len(t.rows) {
+		t.rows[i].collapsed = true
+	}
+	return t
+}
+
+// isTree returns true if any row has a parent
+func (t *Table) isTree() bool {
+	for _, row := range t.rows {
+		if row.parent != TreeRoot {
+			return true
+		}
+	}
+	return false
+}
+
+// treeRows returns copies of the rows in depth-first order with tree guides
+// set on the cells of the tree column
+func treeRows(t *Table) []Row {
+	children := make([][]int, len(t.rows))
+	var roots []int
+	for i, row := range t.rows {
+		switch {
+		case row.parent >= 0 && row.parent < len(t.rows) && row.parent != i:
+			children[row.parent] = append(children[row.parent], i)
+		default:
+			roots = append(roots, i)
+		}
+	}
+
+	var countDescendants func(i int) int
+	countDescendants = func(i int) int {
+		n := len(children[i])
+		for _, child := range children[i] {
+			n += countDescendants(child)
+		}
+		return n
+	}
+
+	var out []Row
+	var walk func(nodes []int, depth int, guides string)
+	walk = func(nodes []int, depth int, guides string) {
+		for n, i := range nodes {
+			last := n == len(nodes)-1
+			row := t.rows[i].clone()
+			cell := &row.cells[t.treeColumn]
+			if depth > 0 {
+				switch {
+				case last:
+					cell.prefix = guides + treeLast
+					cell.indent = guides + treeBlank
+				default:
+					cell.prefix = guides + treeBranch
+					cell.indent = guides + treePipe
+				}
+			}
+
+			hide := row.collapsed || depth == t.collapseDepth
+			if hidden := countDescendants(i); hide && hidden > 0 {
+				cell.value = fmt.Sprintf("%s (+%d)", cell.value, hidden)
+				cell.width = displayWidth(cell.value)
+			}
+			out = append(out, row)
+			if hide {
+				continue
+			}
+
+			var childGuides string
+			switch {
+			case depth == 0:
+			case last:
+				childGuides = guides + treeBlank
+			default:
+				childGuides = guides + treePipe
+			}
+			walk(children[i], depth+1, childGuides)
+		}
+	}
+	t.sortRows(roots)
+	for _, c := range children {
+		t.sortRows(c)
+	}
+	walk(roots, 0, "")
+	return out
+}
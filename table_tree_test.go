@@ -0,0 +1,66 @@
+package clt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func treeTable() *Table {
+	table := NewTable(2)
+	table.pad = 0
+	table.maxWidth = 80
+	org := table.AddTreeRow(TreeRoot, "org", "a")
+	proj1 := table.AddTreeRow(org, "proj1", "b")
+	table.AddTreeRow(proj1, "svc1", "c")
+	table.AddTreeRow(org, "proj2", "d")
+	return table
+}
+
+func TestTreeRows(t *testing.T) {
+	table := treeTable()
+	rows := treeRows(table)
+	var got []string
+	for _, row := range rows {
+		got = append(got, row.cells[0].prefix+row.cells[0].value)
+	}
+	assert.Equal(t, []string{"org", "├─ proj1", "│  └─ svc1", "└─ proj2"}, got)
+}
+
+func TestTreeOrder(t *testing.T) {
+	table := NewTable(1)
+	a := table.AddTreeRow(TreeRoot, "a")
+	table.AddTreeRow(TreeRoot, "b")
+	table.AddTreeRow(a, "a1")
+	rows := treeRows(table)
+	assert.Equal(t, "a", rows[0].cells[0].value)
+	assert.Equal(t, "a1", rows[1].cells[0].value)
+	assert.Equal(t, "b", rows[2].cells[0].value)
+}
+
+func TestTreeCollapse(t *testing.T) {
+	table := treeTable().CollapseTree(1)
+	rows := treeRows(table)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "proj1 (+1)", rows[1].cells[0].value)
+
+	table = treeTable().Collapse(0)
+	rows = treeRows(table)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "org (+3)", rows[0].cells[0].value)
+}
+
+func TestTreeWrapKeepsIndent(t *testing.T) {
+	table := NewTable(1)
+	table.pad = 0
+	table.maxWidth = 80
+	root := table.AddTreeRow(TreeRoot, "root")
+	table.AddTreeRow(root, "first child")
+	table.AddTreeRow(root, "second")
+	v := table.view()
+	v.computeColWidths()
+	v.columns[0].computedWidth = 9
+	d := Styled(Default)
+	want := "├─ " + d.ApplyTo("first") + " \n" + "│  " + d.ApplyTo("child") + " \n"
+	assert.Equal(t, want, renderRow(v.rows[1].cells, v.columns, v.pad, v.spacing))
+}
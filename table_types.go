@@ -0,0 +1,209 @@
+package clt
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType is the kind of data in a column, inferred from the values of its cells
+type ColumnType int
+
+// Column types that can be inferred
+const (
+	Text ColumnType = iota
+	Integer
+	Float
+	Percent
+	Date
+	Boolean
+)
+
+// dateLayouts are the formats recognized as dates during type inference
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"Jan 2, 2006",
+	"02 Jan 2006",
+}
+
+// InferTypes inspects the values in each column when the table is rendered and infers the
+// type of data it holds.  Numeric columns are right-justified and boolean columns are
+// centered.  Justification set explicitly with Justification always takes precedence.
+func InferTypes() TableOption {
+	return func(t *Table) error {
+		t.inferTypes = true
+		return nil
+	}
+}
+
+// SortBy sorts the rows by the values in column col when the table is rendered.  The type of
+// the column is inferred so that numbers, percentages, dates and booleans sort by value
+// rather than alphabetically.  Rows in a tree table are sorted among their siblings.
+func (t *Table) SortBy(col int, descending bool) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if col >= 0 && col < len(t.columns) {
+		t.sortColumn = col
+		t.sortDesc = descending
+	}
+	return t
+}
+
+// ColumnType returns the type inferred from the current values in column col
+func (t *Table) ColumnType(col int) ColumnType {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if col < 0 || col >= len(t.columns) {
+		return Text
+	}
+	return inferType(columnValues(t.rows, col))
+}
+
+// inferJustification sets the justification of each column from its inferred type
+// unless the justification was set explicitly
+func inferJustification(t *Table) {
+	for i := range t.columns {
+		if t.columns[i].justifySet {
+			continue
+		}
+		switch inferType(columnValues(t.rows, i)) {
+		case Integer, Float, Percent:
+			t.columns[i].justify = Right
+		case Boolean:
+			t.columns[i].justify = Center
+		}
+	}
+}
+
+// sortRows sorts the row indexes in order by the sort column
+func (t *Table) sortRows(order []int) {
+	if t.keyed {
+		sort.SliceStable(order, func(a, b int) bool {
+			return t.rows[order[a]].key < t.rows[order[b]].key
+		})
+	}
+	if t.sortColumn < 0 || t.sortColumn >= len(t.columns) || len(order) < 2 {
+		return
+	}
+	col := t.sortColumn
+	typ := inferType(columnValues(t.rows, col))
+	sort.SliceStable(order, func(a, b int) bool {
+		va, vb := t.rows[order[a]].cells[col].value, t.rows[order[b]].cells[col].value
+		if t.sortDesc {
+			va, vb = vb, va
+		}
+		return lessValue(typ, va, vb)
+	})
+}
+
+// lessValue compares two cell values as the given type.  Empty values sort first.
+func lessValue(typ ColumnType, a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" || b == "" {
+		return a == "" && b != ""
+	}
+	switch typ {
+	case Integer, Float, Percent:
+		na, _ := parseNumber(a)
+		nb, _ := parseNumber(b)
+		return na < nb
+	case Date:
+		da, _ := parseDate(a)
+		db, _ := parseDate(b)
+		return da.Before(db)
+	case Boolean:
+		ba, _ := parseBool(a)
+		bb, _ := parseBool(b)
+		return !ba && bb
+	}
+	return a < b
+}
+
+func columnValues(rows []Row, col int) []string {
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if col < len(row.cells) {
+			values = append(values, row.cells[col].value)
+		}
+	}
+	return values
+}
+
+// inferType returns the most specific type that every non-empty value satisfies.  A mix of
+// integers and floats is a float column.  Columns with no values are text.
+func inferType(values []string) ColumnType {
+	var typ ColumnType
+	seen := false
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		vt := valueType(v)
+		switch {
+		case !seen:
+			typ = vt
+			seen = true
+		case typ == vt:
+		case (typ == Integer && vt == Float) || (typ == Float && vt == Integer):
+			typ = Float
+		default:
+			return Text
+		}
+	}
+	return typ
+}
+
+// valueType returns the type of a single value
+func valueType(v string) ColumnType {
+	if _, ok := parseBool(v); ok {
+		return Boolean
+	}
+	if strings.HasSuffix(v, "%") {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(v, "%")), 64); err == nil {
+			return Percent
+		}
+		return Text
+	}
+	if _, err := strconv.ParseInt(strings.Replace(v, ",", "", -1), 10, 64); err == nil {
+		return Integer
+	}
+	if _, err := strconv.ParseFloat(strings.Replace(v, ",", "", -1), 64); err == nil {
+		return Float
+	}
+	if _, ok := parseDate(v); ok {
+		return Date
+	}
+	return Text
+}
+
+// parseNumber parses integers, floats and percentages, ignoring thousands separators
+func parseNumber(v string) (float64, bool) {
+	v = strings.TrimSpace(strings.TrimSuffix(v, "%"))
+	n, err := strconv.ParseFloat(strings.Replace(v, ",", "", -1), 64)
+	return n, err == nil
+}
+
+func parseDate(v string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if d, err := time.Parse(layout, v); err == nil {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseBool(v string) (bool, bool) {
+	switch strings.ToLower(v) {
+	case "true", "yes":
+		return true, true
+	case "false", "no":
+		return false, true
+	}
+	return false, false
+}
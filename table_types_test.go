@@ -0,0 +1,62 @@
+package clt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferType(t *testing.T) {
+	tt := []struct {
+		Name   string
+		Values []string
+		Want   ColumnType
+	}{
+		{Name: "integers", Values: []string{"1", "-20", "1,240", ""}, Want: Integer},
+		{Name: "floats", Values: []string{"1.5", "2"}, Want: Float},
+		{Name: "percent", Values: []string{"10%", "99.5 %"}, Want: Percent},
+		{Name: "dates", Values: []string{"2020-12-22", "2021-01-01"}, Want: Date},
+		{Name: "booleans", Values: []string{"true", "No"}, Want: Boolean},
+		{Name: "mixed", Values: []string{"1", "one"}, Want: Text},
+		{Name: "empty", Values: []string{"", ""}, Want: Text},
+	}
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Want, inferType(tc.Values))
+		})
+	}
+}
+
+func TestInferJustification(t *testing.T) {
+	table := NewTable(4, InferTypes())
+	table.Justification(Left, Left)
+	table.AddRow("10", "20", "30", "yes")
+	table.AddRow("1", "2", "3", "no")
+	v := table.view()
+	assert.Equal(t, Left, v.columns[0].justify)
+	assert.Equal(t, Left, v.columns[1].justify)
+	assert.Equal(t, Right, v.columns[2].justify)
+	assert.Equal(t, Center, v.columns[3].justify)
+	assert.Equal(t, Left, table.columns[2].justify)
+}
+
+func TestSortBy(t *testing.T) {
+	table := NewTable(2)
+	table.AddRow("a", "10")
+	table.AddRow("b", "9")
+	table.AddRow("c", "100")
+
+	table.SortBy(1, false)
+	var got []string
+	for _, row := range table.view().rows {
+		got = append(got, row.cells[0].value)
+	}
+	assert.Equal(t, []string{"b", "a", "c"}, got)
+
+	table.SortBy(1, true)
+	got = nil
+	for _, row := range table.view().rows {
+		got = append(got, row.cells[0].value)
+	}
+	assert.Equal(t, []string{"c", "a", "b"}, got)
+}
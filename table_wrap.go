@@ -0,0 +1,381 @@
+package clt
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultBreakpoints are the characters that word wrapping breaks after when no
+// other breakpoints are given
+const defaultBreakpoints = " :.-/\\"
+
+type wrapKind int
+
+const (
+	wordWrap wrapKind = iota
+	charWrap
+	pathWrap
+	hyphenWrap
+)
+
+// WrapMode controls how text that is too wide for its column is broken into multiple lines.
+// The zero value is word wrapping on the default breakpoints: space, :, ., -, / and \.
+type WrapMode struct {
+	kind        wrapKind
+	breakpoints string
+}
+
+var (
+	// CharWrap cuts lines at exactly the column width without looking for breakpoints
+	CharWrap = WrapMode{kind: charWrap}
+	// PathWrap breaks file paths and URLs after a / or \ when possible, falling back to word
+	// wrapping on the default breakpoints
+	PathWrap = WrapMode{kind: pathWrap}
+	// HyphenWrap breaks lines on spaces and inserts a hyphen when a word is longer than the
+	// column and must be split
+	HyphenWrap = WrapMode{kind: hyphenWrap}
+)
+
+// WordWrap breaks lines after any of the characters in breakpoints.  Words that are longer
+// than the column are cut at the column width.  If breakpoints is empty, the default
+// breakpoints are used.
+func WordWrap(breakpoints string) WrapMode {
+	return WrapMode{kind: wordWrap, breakpoints: breakpoints}
+}
+
+// ColumnWrapModes sets how text is wrapped in each column when it is too wide to fit.  If you
+// pass more modes than the number of columns they will be silently dropped.
+func (t *Table) ColumnWrapModes(modes ...WrapMode) *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, mode := range modes {
+		if i >= len(t.columns) {
+			return t
+		}
+		t.columns[i].wrapMode = mode
+	}
+	return t
+}
+
+// wrap will break long lines on breakpoints space, :, ., /, \, -.  If
+// line is too long without breakpoints, will do dumb wrap at width w.
+// Newlines in s are hard line breaks and each line is wrapped independently.
+func wrap(s string, w int) []string {
+	return wrapWith(s, w, WrapMode{})
+}
+
+// wrapWith wraps s to width w using the wrap mode
+func wrapWith(s string, w int, mode WrapMode) []string {
+	return wrapHighlighted(s, w, mode, nil)
+}
+
+// wrapHighlighted wraps s to width w using the wrap mode and draws the runes covered by hl
+// with the highlight style.  A highlight that is wrapped is drawn on each of its lines.
+func wrapHighlighted(s string, w int, mode WrapMode, hl *highlight) []string {
+	s = strings.TrimRight(s, "\r\n")
+	if !strings.Contains(s, "\n") {
+		return wrapLine(s, w, mode, hl, 0)
+	}
+	var out []string
+	offset := 0
+	for _, line := range strings.Split(s, "\n") {
+		wrapped := wrapLine(strings.TrimRight(line, "\r"), w, mode, hl, offset)
+		if len(wrapped) == 0 {
+			wrapped = []string{""}
+		}
+		out = append(out, wrapped...)
+		// the newline counts as a rune of the value
+		offset += displayWidth(line) + 1
+	}
+	return out
+}
+
+// wrapLine wraps a single line of text without hard line breaks.  Escape sequences take no
+// space and are never split.  Styles and hyperlinks that are active where a line is broken
+// are closed at the end of the line and opened again at the start of the next one.  Offset
+// is the index of the first rune of s in the text that hl refers to.
+func wrapLine(s string, w int, mode WrapMode, hl *highlight, offset int) []string {
+	if w < 1 {
+		w = 1
+	}
+	gs := toGlyphs(s)
+	if len(gs) == 0 {
+		if len(s) == 0 {
+			return nil
+		}
+		return []string{s}
+	}
+	var out []string
+	var state escapeState
+	for len(gs) > 0 {
+		lo, end, next, hyphen := breakLine(gs, w, mode)
+		var line strings.Builder
+		line.WriteString(state.open())
+		lit := false
+		for i, g := range gs[:next] {
+			if i >= lo && i < end && hl.covers(offset+i) != lit {
+				lit = !lit
+				line.WriteString(hl.toggle(lit, state))
+			}
+			for _, seq := range g.pre {
+				line.WriteString(seq)
+				state.apply(seq)
+				line.WriteString(hl.reapply(lit, seq))
+			}
+			if i >= lo && i < end {
+				line.WriteRune(g.r)
+			}
+			for _, seq := range g.post {
+				line.WriteString(seq)
+				state.apply(seq)
+			}
+		}
+		if lit {
+			line.WriteString(hl.sty.after)
+		}
+		if hyphen {
+			line.WriteString("-")
+		}
+		gs = gs[next:]
+		offset += next
+		if len(gs) > 0 {
+			line.WriteString(state.close())
+		}
+		out = append(out, line.String())
+	}
+	return out
+}
+
+// breakLine finds where to break s to fit width w using the wrap mode.  The line shows
+// s[lo:end] followed by a hyphen if hyphen is set, and the next line starts at s[next].
+func breakLine(s []glyph, w int, mode WrapMode) (lo, end, next int, hyphen bool) {
+	if len(s) <= w {
+		lo, end = trimSpace(s, 0, len(s))
+		return lo, end, len(s), false
+	}
+
+	switch mode.kind {
+	case charWrap:
+		end = w
+		for end > 0 && unicode.IsSpace(s[end-1].r) {
+			end--
+		}
+		return 0, end, skipSpace(s, w), false
+	case pathWrap:
+		if ind := lastIndexAny(s[0:w], "/\\"); ind > 0 {
+			return splitAt(s, ind+1)
+		}
+	case hyphenWrap:
+		if ind := lastIndexAny(s[0:w], " "); ind > 0 {
+			return splitAt(s, ind+1)
+		}
+		if w > 1 && !unicode.IsSpace(s[w-2].r) && !unicode.IsSpace(s[w-1].r) {
+			return 0, w - 1, skipSpace(s, w-1), true
+		}
+		return splitAt(s, w)
+	}
+
+	breakpts := mode.breakpoints
+	if len(breakpts) == 0 {
+		breakpts = defaultBreakpoints
+	}
+	if ind := lastIndexAny(s[0:w], breakpts); ind > 0 {
+		return splitAt(s, ind+1)
+	}
+	return splitAt(s, w)
+}
+
+// splitAt breaks s at index i, trimming whitespace around the break
+func splitAt(s []glyph, i int) (lo, end, next int, hyphen bool) {
+	lo, end = trimSpace(s, 0, i)
+	return lo, end, skipSpace(s, i), false
+}
+
+// trimSpace returns the bounds of s[lo:end] without leading and trailing whitespace
+func trimSpace(s []glyph, lo, end int) (int, int) {
+	for lo < end && unicode.IsSpace(s[lo].r) {
+		lo++
+	}
+	for end > lo && unicode.IsSpace(s[end-1].r) {
+		end--
+	}
+	return lo, end
+}
+
+// skipSpace returns the index of the first non-whitespace glyph at or after i
+func skipSpace(s []glyph, i int) int {
+	for i < len(s) && unicode.IsSpace(s[i].r) {
+		i++
+	}
+	return i
+}
+
+// lastIndexAny returns the index of the last glyph in s that is in chars or -1
+func lastIndexAny(s []glyph, chars string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if strings.ContainsRune(chars, s[i].r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// glyph is a visible rune with the escape sequences that come before it.  Escape sequences
+// at the end of the text are kept in post of the last glyph.
+type glyph struct {
+	pre  []string
+	r    rune
+	post []string
+}
+
+// toGlyphs splits s into glyphs, attaching each escape sequence to the next visible rune
+func toGlyphs(s string) []glyph {
+	var out []glyph
+	var pre []string
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			n := escapeLen(s[i:])
+			pre = append(pre, s[i:i+n])
+			i += n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		out = append(out, glyph{pre: pre, r: r})
+		pre = nil
+		i += n
+	}
+	if len(pre) > 0 && len(out) > 0 {
+		out[len(out)-1].post = pre
+	}
+	return out
+}
+
+// escapeState tracks the styles and hyperlink that are active at a point in a line so that
+// they can be closed at the end of a wrapped line and opened again on the next one
+type escapeState struct {
+	sgr  []sgrAttr
+	link string
+}
+
+// sgrAttr is an active text attribute and the codes that set it
+type sgrAttr struct {
+	kind  string
+	codes string
+}
+
+// apply updates the state with an escape sequence
+func (st *escapeState) apply(seq string) {
+	switch {
+	case strings.HasPrefix(seq, "\x1b]8;"):
+		// OSC 8 hyperlinks are ESC ] 8 ; params ; url ST, and an empty url ends the link
+		body := strings.TrimSuffix(strings.TrimSuffix(seq[4:], "\a"), "\x1b\\")
+		if i := strings.IndexByte(body, ';'); i >= 0 && i+1 < len(body) {
+			st.link = seq
+			return
+		}
+		st.link = ""
+	case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m"):
+		codes := strings.Split(seq[2:len(seq)-1], ";")
+		for i := 0; i < len(codes); i++ {
+			code := codes[i]
+			// extended colors take two or four more parameters
+			if (code == "38" || code == "48") && i+1 < len(codes) {
+				n := 2
+				if codes[i+1] == "2" {
+					n = 4
+				}
+				if i+n < len(codes) {
+					code = strings.Join(codes[i:i+n+1], ";")
+					i += n
+				}
+			}
+			st.sgrCode(code)
+		}
+	}
+}
+
+// sgrCode updates the active attributes with a single SGR code
+func (st *escapeState) sgrCode(code string) {
+	kind, set := sgrKind(code)
+	switch {
+	case kind == "reset":
+		st.sgr = nil
+		return
+	case kind == "":
+		st.sgr = append(st.sgr, sgrAttr{codes: code})
+		return
+	}
+	for i, a := range st.sgr {
+		if a.kind == kind {
+			st.sgr = append(st.sgr[:i], st.sgr[i+1:]...)
+			break
+		}
+	}
+	if set {
+		st.sgr = append(st.sgr, sgrAttr{kind: kind, codes: code})
+	}
+}
+
+// sgrKind returns the kind of attribute an SGR code changes and whether it sets or clears it.
+// Unknown codes have no kind.
+func sgrKind(code string) (kind string, set bool) {
+	n, err := strconv.Atoi(code)
+	switch {
+	case code == "" || code == "0":
+		return "reset", false
+	case strings.HasPrefix(code, "38;"):
+		return "fg", true
+	case strings.HasPrefix(code, "48;"):
+		return "bg", true
+	case err != nil:
+		return "", false
+	case n == 1 || n == 2:
+		return "intensity", true
+	case n == 22:
+		return "intensity", false
+	case n == 3, n == 4, n == 5, n == 7, n == 8, n == 9:
+		return code, true
+	case n >= 23 && n <= 29:
+		return strconv.Itoa(n - 20), false
+	case (n >= 30 && n <= 37) || (n >= 90 && n <= 97):
+		return "fg", true
+	case n == 39:
+		return "fg", false
+	case (n >= 40 && n <= 47) || (n >= 100 && n <= 107):
+		return "bg", true
+	case n == 49:
+		return "bg", false
+	}
+	return "", false
+}
+
+// open returns the escape sequences that restore the state
+func (st escapeState) open() string {
+	return st.openStyles() + st.link
+}
+
+// openStyles returns the escape sequence that restores the active styles
+func (st escapeState) openStyles() string {
+	if len(st.sgr) == 0 {
+		return ""
+	}
+	codes := make([]string, len(st.sgr))
+	for i, a := range st.sgr {
+		codes[i] = a.codes
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// close returns the escape sequences that end any active styles and hyperlink
+func (st escapeState) close() string {
+	var out string
+	if st.link != "" {
+		out += "\x1b]8;;\x1b\\"
+	}
+	if len(st.sgr) > 0 {
+		out += "\x1b[0m"
+	}
+	return out
+}
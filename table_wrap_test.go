@@ -0,0 +1,57 @@
+package clt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapModes(t *testing.T) {
+	tt := []struct {
+		Name  string
+		Mode  WrapMode
+		Value string
+		Width int
+		Want  []string
+	}{
+		{Name: "default word wrap", Mode: WrapMode{}, Value: "hello world:again", Width: 8, Want: []string{"hello", "world:", "again"}},
+		{Name: "custom breakpoints", Mode: WordWrap(","), Value: "a b c,d e f", Width: 7, Want: []string{"a b c,", "d e f"}},
+		{Name: "char wrap", Mode: CharWrap, Value: "hello world", Width: 4, Want: []string{"hell", "o wo", "rld"}},
+		{Name: "path wrap", Mode: PathWrap, Value: "/usr/local/share/doc", Width: 12, Want: []string{"/usr/local/", "share/doc"}},
+		{Name: "path wrap falls back to words", Mode: PathWrap, Value: "no slashes here", Width: 10, Want: []string{"no", "slashes", "here"}},
+		{Name: "hyphen wrap", Mode: HyphenWrap, Value: "extraordinary", Width: 6, Want: []string{"extra-", "ordin-", "ary"}},
+		{Name: "hyphen wrap prefers spaces", Mode: HyphenWrap, Value: "an extraordinary", Width: 6, Want: []string{"an", "extra-", "ordin-", "ary"}},
+		{Name: "multibyte", Mode: WrapMode{}, Value: "ééééé ééé", Width: 6, Want: []string{"ééééé", "ééé"}},
+		{Name: "leading breakpoint", Mode: WrapMode{}, Value: "/abcdefgh", Width: 4, Want: []string{"/abc", "defg", "h"}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Want, wrapWith(tc.Value, tc.Width, tc.Mode))
+		})
+	}
+}
+
+func TestColumnWrapModes(t *testing.T) {
+	table := NewTable(2).ColumnWrapModes(CharWrap, PathWrap, HyphenWrap)
+	assert.Equal(t, CharWrap, table.columns[0].wrapMode)
+	assert.Equal(t, PathWrap, table.columns[1].wrapMode)
+}
+
+func TestWrapEscapes(t *testing.T) {
+	red := Styled(Red)
+	assert.Equal(t, []string{red.ApplyTo("hello")}, wrap(red.ApplyTo("hello"), 5))
+	assert.Equal(t, []string{"\x1b[31mhello\x1b[0m", "\x1b[31mworld\x1b[39m"}, wrap(red.ApplyTo("hello world"), 6))
+	assert.Equal(t, []string{"ab" + red.ApplyTo("c") + "d", "ef"}, wrapWith("ab"+red.ApplyTo("c")+"def", 4, CharWrap))
+	assert.Equal(t, []string{red.ApplyTo("")}, wrap(red.ApplyTo(""), 5))
+	assert.Equal(t, []string{"\x1b[31;1mhello\x1b[0m", "\x1b[31;1mworld\x1b[39;22m"}, wrap(Styled(Red, Bold).ApplyTo("hello world"), 6))
+	assert.Equal(t, []string{"\x1b[38;5;200mab\x1b[0m", "\x1b[38;5;200mcd"}, wrapWith("\x1b[38;5;200mabcd", 2, CharWrap))
+}
+
+func TestRenderStyledValue(t *testing.T) {
+	table := NewTable(1)
+	table.AddRow(Styled(Red).ApplyTo("hello"))
+	lines := strings.Split(strings.TrimSuffix(table.AsString(), "\n"), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, " "+Styled(Default).ApplyTo(Styled(Red).ApplyTo("hello"))+" ", lines[2])
+}